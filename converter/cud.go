@@ -93,20 +93,7 @@ func (repository *repository[E, C, M]) DeleteAll(ctx context.Context, models []M
 
 // DeleteAllBy deletes all entities of type E matching the given Where clause conditions.
 func (repository repository[E, _, _]) DeleteAllBy(ctx context.Context, where gormen.Where) error {
-	query := repository.db.WithContext(ctx)
-
-	for _, join := range where.Joins() {
-		query = query.Joins(join)
-	}
-
-	for cond, op := range where.Conditions() {
-		switch op {
-		case types.Or:
-			query = query.Or(cond.Get())
-		default:
-			query = query.Where(cond.Get())
-		}
-	}
+	query := where.Apply(repository.db.WithContext(ctx))
 
 	query = query.Delete(*new(E))
 	if err := query.Error; err != nil {
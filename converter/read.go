@@ -5,7 +5,6 @@ import (
 	"errors"
 
 	"github.com/javiorfo/gormen"
-	"github.com/javiorfo/gormen/internal/types"
 	"github.com/javiorfo/gormen/pagination"
 	"github.com/javiorfo/gormen/pagination/sort"
 	"github.com/javiorfo/nilo"
@@ -31,28 +30,12 @@ func (repository *repository[E, C, M]) FindAllPaginatedBy(ctx context.Context, p
 		return &pagination.Page[M]{Total: total}, nil
 	}
 
-	query := repository.db.WithContext(ctx)
-	for _, preload := range preloads {
-		query = query.Preload(preload)
-	}
-	for _, join := range where.Joins() {
-		query = query.Joins(join)
-	}
-
-	var entities []E
-	page, err := pageable.Paginate(query)
+	page, err := repository.pageQuery(repository.db.WithContext(ctx), pageable, where, preloads...)
 	if err != nil {
 		return nil, err
 	}
 
-	for cond, op := range where.Conditions() {
-		if op == types.Or {
-			query = query.Or(cond.Get())
-		} else {
-			query = query.Where(cond.Get())
-		}
-	}
-
+	var entities []E
 	results := page.Find(&entities)
 	if err := results.Error; err != nil {
 		return nil, err
@@ -77,16 +60,7 @@ func (repository *repository[E, C, M]) FindAllBy(ctx context.Context, where gorm
 	for _, preload := range preloads {
 		query = query.Preload(preload)
 	}
-	for _, join := range where.Joins() {
-		query = query.Joins(join)
-	}
-	for cond, op := range where.Conditions() {
-		if op == types.Or {
-			query = query.Or(cond.Get())
-		} else {
-			query = query.Where(cond.Get())
-		}
-	}
+	query = where.Apply(query)
 
 	var entities []E
 	results := query.Find(&entities)
@@ -108,8 +82,8 @@ func (repository *repository[E, C, M]) FindAllOrdered(ctx context.Context, order
 	for _, preload := range preloads {
 		query = query.Preload(preload)
 	}
-	for _, o := range orders {
-		query = query.Order(o.Get())
+	if len(orders) > 0 {
+		query = query.Order(sort.OrderBy(orders))
 	}
 
 	var entities []E
@@ -126,39 +100,71 @@ func (repository *repository[E, C, M]) FindAllOrdered(ctx context.Context, order
 	return models, nil
 }
 
-// count returns the total number of records matching the Pageable's filter criteria and preloads.
+// count calculates the total number of records available based on the Pageable filtering and preloads.
 func (repository repository[E, _, _]) count(ctx context.Context, pageable pagination.Pageable, where gormen.Where, preloads ...gormen.Preload) (int64, error) {
-	query := repository.db.WithContext(ctx)
+	query, err := repository.countQuery(repository.db.WithContext(ctx), pageable, where, preloads...)
+	if err != nil {
+		return 0, err
+	}
 
-	for _, preload := range preloads {
-		query = query.Preload(preload)
+	var count int64
+	results := query.Count(&count)
+	if err := results.Error; err != nil {
+		return 0, err
 	}
 
-	for _, join := range where.Joins() {
-		query = query.Joins(join)
+	return count, nil
+}
+
+// countQuery builds, on top of the given GORM DB, the query counting the records
+// that match the Where clause and the Pageable filtering.
+func (repository repository[E, _, _]) countQuery(db *gorm.DB, pageable pagination.Pageable, where gormen.Where, preloads ...gormen.Preload) (*gorm.DB, error) {
+	for _, preload := range preloads {
+		db = db.Preload(preload)
 	}
 
-	for cond, op := range where.Conditions() {
-		if op == types.Or {
-			query = query.Or(cond.Get())
-		} else {
-			query = query.Where(cond.Get())
-		}
+	db = where.Apply(db).Model(*new(E))
+
+	return pageable.Filter(db)
+}
+
+// pageQuery builds, on top of the given GORM DB, the query selecting the page of records
+// that match the Where clause, applying the Pageable pagination, sorting and filtering.
+func (repository repository[E, _, _]) pageQuery(db *gorm.DB, pageable pagination.Pageable, where gormen.Where, preloads ...gormen.Preload) (*gorm.DB, error) {
+	for _, preload := range preloads {
+		db = db.Preload(preload)
 	}
 
-	query = query.Model(*new(E))
+	return pageable.Paginate(where.Apply(db))
+}
+
+// Explain renders, without running them, the count and page statements that FindAllPaginatedBy
+// runs for the same arguments, with their bound parameters.
+func (repository *repository[E, C, M]) Explain(ctx context.Context, pageable pagination.Pageable, where gormen.Where, preloads ...gormen.Preload) (*gormen.Explanation, error) {
+	db := repository.db.Session(&gorm.Session{DryRun: true, Context: ctx})
 
-	filteredQuery, err := pageable.Filter(query)
+	countQuery, err := repository.countQuery(db, pageable, where, preloads...)
 	if err != nil {
-		return 0, err
+		return nil, err
 	}
 
 	var count int64
-	result := filteredQuery.Count(&count)
-	if err := result.Error; err != nil {
-		return 0, err
+	countStatement, err := gormen.StatementOf(countQuery.Count(&count))
+	if err != nil {
+		return nil, err
 	}
-	return count, nil
+
+	pageQuery, err := repository.pageQuery(db, pageable, where, preloads...)
+	if err != nil {
+		return nil, err
+	}
+
+	pageStatement, err := gormen.StatementOf(pageQuery.Find(&[]E{}))
+	if err != nil {
+		return nil, err
+	}
+
+	return &gormen.Explanation{Count: countStatement, Page: pageStatement}, nil
 }
 
 // FindBy retrieves the first record matching the Where conditions with preloads,
@@ -168,16 +174,7 @@ func (repository *repository[E, C, M]) FindBy(ctx context.Context, where gormen.
 	for _, preload := range preloads {
 		query = query.Preload(preload)
 	}
-	for _, join := range where.Joins() {
-		query = query.Joins(join)
-	}
-	for cond, op := range where.Conditions() {
-		if op == types.Or {
-			query = query.Or(cond.Get())
-		} else {
-			query = query.Where(cond.Get())
-		}
-	}
+	query = where.Apply(query)
 
 	var entity C = new(E)
 	result := query.First(&entity)
@@ -200,17 +197,7 @@ func (repository repository[E, _, _]) Count(ctx context.Context) (int64, error)
 
 // CountBy returns count of records matching the Where conditions.
 func (repository repository[E, _, _]) CountBy(ctx context.Context, where gormen.Where) (int64, error) {
-	query := repository.db.WithContext(ctx)
-	for _, join := range where.Joins() {
-		query = query.Joins(join)
-	}
-	for cond, op := range where.Conditions() {
-		if op == types.Or {
-			query = query.Or(cond.Get())
-		} else {
-			query = query.Where(cond.Get())
-		}
-	}
+	query := where.Apply(repository.db.WithContext(ctx))
 
 	var count int64
 	results := query.Model(*new(E)).Count(&count)
@@ -34,6 +34,6 @@ func (udb UserDB) Into() model.User {
 }
 
 type UserFilter struct {
-	Username    string `filter:"username = ?"`
-	PersonEmail string `filter:"people.email in (?);join:inner join people on people.id = users.person_id"`
+	Username    string `param:"username" filter:"column:username;op:contains"`
+	PersonEmail string `param:"person.email" filter:"people.email in (?);join:inner join people on people.id = users.person_id"`
 }
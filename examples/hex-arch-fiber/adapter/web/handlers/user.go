@@ -1,13 +1,15 @@
 package handlers
 
 import (
+	"net/url"
+	"strconv"
+
 	"hex-arch-fiber/adapter/database/entities"
 	"hex-arch-fiber/adapter/web/response"
 	"hex-arch-fiber/port"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/javiorfo/gormen/pagination"
-	"github.com/javiorfo/gormen/pagination/sort"
 )
 
 func FindByUsername(service port.UserService) fiber.Handler {
@@ -24,20 +26,15 @@ func FindByUsername(service port.UserService) fiber.Handler {
 
 func FindAll(service port.UserService) fiber.Handler {
 	return func(c *fiber.Ctx) error {
-		number := c.Query("pageNumber", "1")
-		size := c.Query("pageSize", "10")
-
-		pageRequest, err := pagination.PageRequestFrom(
-			number,
-			size,
-			pagination.WithFilter(entities.UserFilter{
-				Username:    c.Get("username"),
-				PersonEmail: c.Get("person.email"),
-			}),
-			pagination.WithSortOrder(
-				c.Query("order", "id"),
-				sort.DirectionFromString(c.Query("direction", "asc")),
-			),
+		values, err := url.ParseQuery(string(c.Request().URI().QueryString()))
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error:": err.Error()})
+		}
+
+		pageRequest, err := pagination.PageRequestFromQuery(
+			values,
+			pagination.QueryConfig{MaxPageSize: 100, Sortable: []string{"id", "username"}},
+			pagination.WithFilterFromQuery[entities.UserFilter](values),
 		)
 
 		if err != nil {
@@ -52,8 +49,8 @@ func FindAll(service port.UserService) fiber.Handler {
 		return c.Status(fiber.StatusOK).JSON(response.UsersResponse{
 			Users: page.Elements,
 			PageInfo: response.PageInfo{
-				Number: number,
-				Size:   size,
+				Number: strconv.Itoa(pageRequest.PageNumber()),
+				Size:   strconv.Itoa(pageRequest.PageSize()),
 				Total:  page.Total,
 			},
 		})
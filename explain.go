@@ -0,0 +1,48 @@
+package gormen
+
+import "gorm.io/gorm"
+
+// Statement holds a SQL statement rendered without running it.
+type Statement struct {
+	// SQL with placeholders for the bound parameters
+	SQL string
+	// Bound parameters, in placeholder order
+	Vars []any
+	// SQL with the bound parameters interpolated, meant for logging only
+	Explained string
+}
+
+// String returns the SQL with the bound parameters interpolated.
+func (s Statement) String() string {
+	return s.Explained
+}
+
+// Explanation holds the statements FindAllPaginatedBy runs for the same arguments.
+type Explanation struct {
+	// Statement counting the total number of records
+	Count Statement
+	// Statement selecting the records of the page
+	Page Statement
+}
+
+// StatementOf returns the Statement rendered by a GORM query executed in DryRun mode,
+// or the error found while rendering it.
+func StatementOf(db *gorm.DB) (Statement, error) {
+	if err := db.Error; err != nil {
+		return Statement{}, err
+	}
+
+	sql := db.Statement.SQL.String()
+	return Statement{
+		SQL:       sql,
+		Vars:      db.Statement.Vars,
+		Explained: db.Dialector.Explain(sql, db.Statement.Vars...),
+	}, nil
+}
+
+// ToSQL renders, without running it, the statement selecting the records of the model
+// that match the Where clause. The model must be a pointer to an entity, e.g. &UserDB{}.
+func (w Where) ToSQL(db *gorm.DB, model any) (Statement, error) {
+	query := w.Apply(db.Session(&gorm.Session{DryRun: true, NewDB: true})).Model(model)
+	return StatementOf(query.Find(model))
+}
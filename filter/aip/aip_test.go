@@ -0,0 +1,170 @@
+package aip
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/javiorfo/gormen/filter"
+	"github.com/javiorfo/gormen/internal/testutils"
+	"github.com/javiorfo/gormen/pagination"
+)
+
+var registry = filter.Registry{
+	"id":          {Column: "id", Kind: filter.Int},
+	"username":    {Column: "username", Kind: filter.String},
+	"person.name": {Column: "Person.name", Kind: filter.String, Join: "Person"},
+}
+
+func TestParseFilter(t *testing.T) {
+	db := testutils.SetupDryRunDB("sqlite")
+
+	tests := []struct {
+		name  string
+		input string
+		sql   string
+	}{
+		{"Blank", "", "SELECT * FROM `users`"},
+		{"Has with wildcard", `id >= 2 AND person.name:"Bat*"`,
+			"SELECT `users`.`id`,`users`.`username`,`users`.`password`,`users`.`person_id`,`Person`.`id` AS `Person__id`,`Person`.`name` AS `Person__name`,`Person`.`email` AS `Person__email` " +
+				"FROM `users` LEFT JOIN `persons` `Person` ON `users`.`person_id` = `Person`.`id` " +
+				"WHERE (`users`.`id` >= 2 AND `Person`.`name` like \"Bat%\" escape '!')"},
+		{"OR binds tighter than AND", "id = 1 AND id = 2 OR id = -3",
+			"SELECT * FROM `users` WHERE (`users`.`id` = 1 AND (`users`.`id` = 2 OR `users`.`id` = -3))"},
+		{"Implicit AND, negation and groups", `username != "a b" -id < 5 NOT (username:* OR username = null)`,
+			"SELECT * FROM `users` WHERE (`users`.`username` <> \"a b\" AND NOT (`users`.`id` < 5) AND NOT ((`users`.`username` is not null OR `users`.`username` is null)))"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w, err := ParseFilter(tt.input, registry)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			statement, err := w.ToSQL(db, &testutils.UserDB{})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if sql := statement.String(); sql != tt.sql {
+				t.Errorf("expected %q, got %q", tt.sql, sql)
+			}
+		})
+	}
+}
+
+func TestParseFilter_Errors(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		position int
+	}{
+		{"Unknown field", "id = 1 AND password = 2", 11},
+		{"Global restriction", `id = 1 "text"`, 7},
+		{"Missing comparator", "id = 1 username", 15},
+		{"Function", `id = 1 AND timestamp("2026-10-18")`, 11},
+		{"Invalid value", "id > one", 5},
+		{"Missing value", "id =", 4},
+		{"Unclosed group", "(id = 1", 7},
+		{"Unterminated string", `username = "abc`, 11},
+		{"Trailing input", "id = 1)", 6},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseFilter(tt.input, registry)
+
+			var syntaxErr *filter.SyntaxError
+			if !errors.As(err, &syntaxErr) {
+				t.Fatalf("expected a syntax error, got %v", err)
+			}
+
+			if syntaxErr.Position != tt.position {
+				t.Errorf("expected position %d, got %d (%v)", tt.position, syntaxErr.Position, err)
+			}
+		})
+	}
+}
+
+func TestParseOrderBy(t *testing.T) {
+	orders, joins, err := ParseOrderBy("username desc,  id, person.name asc", registry)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := []string{"username desc", "id asc", "Person.name asc"}
+	if len(orders) != len(expected) {
+		t.Fatalf("expected %v, got %v", expected, orders)
+	}
+	for i, o := range orders {
+		if o.Get() != expected[i] {
+			t.Errorf("expected order %q, got %q", expected[i], o.Get())
+		}
+	}
+
+	if _, err := pagination.PageRequestFrom(1, 10, pagination.WithOrder(orders...)); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	if j := joins.Joins(); len(j) != 1 || j[0] != "Person" {
+		t.Errorf("expected the Person join, got %v", j)
+	}
+
+	if orders, joins, err := ParseOrderBy(" ", registry); err != nil || len(orders) != 0 || len(joins.Joins()) != 0 {
+		t.Errorf("expected no orders, got %v, %v", orders, err)
+	}
+}
+
+func TestParseOrderBy_Joins(t *testing.T) {
+	orders, joins, err := ParseOrderBy("person.name desc, username", registry)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	pageRequest, err := pagination.PageRequestFrom(1, 10, pagination.WithOrder(orders...))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	w, err := ParseFilter("id >= 1", registry)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	w.Merge(joins)
+
+	db, err := pageRequest.Paginate(w.Apply(testutils.SetupTestDB().Model(&testutils.UserDB{})))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := db.Find(&[]testutils.UserDB{}).Error; err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestParseOrderBy_Errors(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		position int
+	}{
+		{"Unknown field", "id, password", 4},
+		{"Invalid direction", "id descending", 3},
+		{"Too many words", "id desc id", 8},
+		{"Empty field", "id,", 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := ParseOrderBy(tt.input, registry)
+
+			var syntaxErr *filter.SyntaxError
+			if !errors.As(err, &syntaxErr) {
+				t.Fatalf("expected a syntax error, got %v", err)
+			}
+
+			if syntaxErr.Position != tt.position {
+				t.Errorf("expected position %d, got %d (%v)", tt.position, syntaxErr.Position, err)
+			}
+		})
+	}
+}
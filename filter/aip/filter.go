@@ -0,0 +1,326 @@
+// Package aip parses the filter and order_by request fields of the Google API design guidelines,
+// AIP-160 filters into a gormen.Where and AIP-132 orderings into sort orders.
+package aip
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"unicode"
+
+	"github.com/javiorfo/gormen"
+	"github.com/javiorfo/gormen/filter"
+	"github.com/javiorfo/gormen/where"
+)
+
+// comparators maps the AIP-160 comparators to filter operators; ':' (has) compares as '='.
+var comparators = map[string]filter.Operator{
+	"=":  filter.Equal,
+	":":  filter.Equal,
+	"!=": filter.NotEqual,
+	"<":  filter.LessThan,
+	"<=": filter.LessOrEqual,
+	">":  filter.GreaterThan,
+	">=": filter.GreaterOrEqual,
+}
+
+// ParseFilter converts an AIP-160 filter, such as enable = true AND person.name:"Bat*",
+// into a gormen.Where with the joins of the fields it references.
+// Fields are looked up by public name in the registry, and their values converted to the field kind.
+// It supports AND, OR (which, as in AIP-160, binds tighter than AND), NOT or -, parentheses,
+// implicit AND between terms and the =, !=, <, <=, >, >= and : comparators.
+// A * in a string compared with =, != or : matches any sequence of characters; field:* matches
+// any non null value and null compares with = and != as a literal.
+// Global restrictions, without a field, and functions are not supported.
+// Any problem is reported as a *filter.SyntaxError with its position in the input.
+// A blank input returns an empty Where.
+func ParseFilter(input string, registry filter.Registry) (gormen.Where, error) {
+	p := &parser{lexer: lexer{input: input}, registry: registry}
+
+	if p.peek().kind == end {
+		return gormen.Where{}, nil
+	}
+
+	condition, err := p.expression()
+	if err != nil {
+		return gormen.Where{}, err
+	}
+
+	if t := p.peek(); t.kind != end {
+		return gormen.Where{}, t.errorf("unexpected '%s'", t.text)
+	}
+
+	return gormen.NewWhere(condition).WithJoin(p.joins...).Build(), nil
+}
+
+// parser is a recursive descent parser over the tokens of the lexer,
+// collecting the joins of the fields found.
+type parser struct {
+	lexer
+	registry filter.Registry
+	joins    []gormen.Join
+}
+
+// expression parses sequences separated by AND.
+func (p *parser) expression() (where.Condition, error) {
+	return p.list(p.sequence, "AND", func(c ...where.Condition) where.Condition { return where.AllOf(c...) })
+}
+
+// sequence parses factors separated by spaces, an implicit AND.
+func (p *parser) sequence() (where.Condition, error) {
+	var conditions []where.Condition
+	for {
+		c, err := p.factor()
+		if err != nil {
+			return nil, err
+		}
+		conditions = append(conditions, c)
+
+		if t := p.peek(); t.kind == end || t.kind == closing || t.is("AND") {
+			break
+		}
+	}
+
+	if len(conditions) == 1 {
+		return conditions[0], nil
+	}
+	return where.AllOf(conditions...), nil
+}
+
+// factor parses terms separated by OR.
+func (p *parser) factor() (where.Condition, error) {
+	return p.list(p.term, "OR", func(c ...where.Condition) where.Condition { return where.AnyOf(c...) })
+}
+
+// list parses elements separated by the keyword, grouping them when there are many.
+func (p *parser) list(element func() (where.Condition, error), keyword string, group func(...where.Condition) where.Condition) (where.Condition, error) {
+	var conditions []where.Condition
+	for {
+		c, err := element()
+		if err != nil {
+			return nil, err
+		}
+		conditions = append(conditions, c)
+
+		if !p.peek().is(keyword) {
+			break
+		}
+		p.next()
+	}
+
+	if len(conditions) == 1 {
+		return conditions[0], nil
+	}
+	return group(conditions...), nil
+}
+
+// term parses a simple expression, optionally negated with NOT or -.
+func (p *parser) term() (where.Condition, error) {
+	if t := p.peek(); t.is("NOT") || t.kind == minus {
+		p.next()
+		c, err := p.simple()
+		if err != nil {
+			return nil, err
+		}
+		return where.Not(c), nil
+	}
+
+	return p.simple()
+}
+
+// simple parses a parenthesized expression or a restriction.
+func (p *parser) simple() (where.Condition, error) {
+	t := p.next()
+
+	switch t.kind {
+	case opening:
+		c, err := p.expression()
+		if err != nil {
+			return nil, err
+		}
+		if t := p.next(); t.kind != closing {
+			return nil, t.errorf("expected ')'")
+		}
+		return c, nil
+	case text:
+		return p.restriction(t)
+	case end:
+		return nil, t.errorf("expected field name")
+	case unterminated:
+		return nil, t.errorf("unterminated quoted string")
+	default:
+		return nil, t.errorf("unexpected '%s'", t.text)
+	}
+}
+
+// restriction parses the comparator and value following a field name.
+func (p *parser) restriction(name token) (where.Condition, error) {
+	if p.peek().kind == opening {
+		return nil, name.errorf("functions are not supported")
+	}
+
+	comparator := p.next()
+	if comparator.kind != comparison {
+		return nil, comparator.errorf("expected comparator after '%s'", name.text)
+	}
+
+	field, err := p.registry.Lookup(name.text)
+	if err != nil {
+		return nil, name.errorf("%s", err)
+	}
+
+	value := p.next()
+	if value.kind == unterminated {
+		return nil, value.errorf("unterminated quoted string")
+	}
+	if value.kind != text && value.kind != quoted {
+		return nil, value.errorf("expected value")
+	}
+	if p.peek().kind == opening {
+		return nil, value.errorf("functions are not supported")
+	}
+
+	operator := comparators[comparator.text]
+	switch {
+	case comparator.text == ":" && value.kind == text && value.text == "*":
+		operator, value.text = filter.IsNull, "false"
+	case (operator == filter.Equal || operator == filter.NotEqual) && value.kind == text && value.text == "null":
+		operator, value.text = filter.IsNull, fmt.Sprint(operator == filter.Equal)
+	case field.Kind == filter.String && strings.Contains(value.text, "*"):
+		switch operator {
+		case filter.Equal:
+			operator = filter.Like
+		case filter.NotEqual:
+			operator = filter.NotLike
+		}
+	}
+
+	condition, err := field.Condition(operator, value.text)
+	if err != nil {
+		return nil, value.errorf("%s", err)
+	}
+
+	if field.Join != "" && !slices.Contains(p.joins, field.Join) {
+		p.joins = append(p.joins, field.Join)
+	}
+
+	return condition, nil
+}
+
+// kind of a token.
+type kind int
+
+const (
+	end kind = iota
+	text
+	quoted
+	comparison
+	opening
+	closing
+	minus
+	unterminated
+)
+
+// token is a lexical unit of a query, with its position in the input.
+type token struct {
+	kind     kind
+	text     string
+	position int
+}
+
+// is reports whether the token is the given keyword.
+func (t token) is(keyword string) bool {
+	return t.kind == text && t.text == keyword
+}
+
+// errorf returns a SyntaxError at the position of the token.
+func (t token) errorf(format string, args ...any) error {
+	return &filter.SyntaxError{Position: t.position, Message: fmt.Sprintf(format, args...)}
+}
+
+// lexer splits the input into tokens, one token ahead.
+type lexer struct {
+	input  string
+	pos    int
+	peeked *token
+}
+
+// peek returns the next token without consuming it.
+func (l *lexer) peek() token {
+	if l.peeked == nil {
+		t := l.scan()
+		l.peeked = &t
+	}
+	return *l.peeked
+}
+
+// next consumes and returns the next token.
+func (l *lexer) next() token {
+	t := l.peek()
+	l.peeked = nil
+	return t
+}
+
+// scan reads the token at the current position.
+func (l *lexer) scan() token {
+	for l.pos < len(l.input) && unicode.IsSpace(rune(l.input[l.pos])) {
+		l.pos++
+	}
+
+	start := l.pos
+	if start == len(l.input) {
+		return token{end, "", start}
+	}
+
+	rest := l.input[start:]
+	switch c := rest[0]; {
+	case c == '(':
+		l.pos++
+		return token{opening, "(", start}
+	case c == ')':
+		l.pos++
+		return token{closing, ")", start}
+	case c == '-' && (len(rest) == 1 || !unicode.IsDigit(rune(rest[1]))):
+		l.pos++
+		return token{minus, "-", start}
+	case c == '"' || c == '\'':
+		return l.quoted(c)
+	}
+
+	for _, symbol := range []string{"<=", ">=", "!=", "<", ">", "=", ":"} {
+		if strings.HasPrefix(rest, symbol) {
+			l.pos += len(symbol)
+			return token{comparison, symbol, start}
+		}
+	}
+
+	for l.pos < len(l.input) && !unicode.IsSpace(rune(l.input[l.pos])) && !strings.ContainsRune(`()"'<>=!:`, rune(l.input[l.pos])) {
+		l.pos++
+	}
+	return token{text, l.input[start:l.pos], start}
+}
+
+// quoted reads a string delimited by the quote, unescaping backslash escapes.
+// An unterminated string is returned as a token of kind unterminated.
+func (l *lexer) quoted(quote byte) token {
+	start := l.pos
+	l.pos++
+
+	var value strings.Builder
+	for l.pos < len(l.input) {
+		c := l.input[l.pos]
+		switch {
+		case c == '\\' && l.pos+1 < len(l.input):
+			value.WriteByte(l.input[l.pos+1])
+			l.pos += 2
+		case c == quote:
+			l.pos++
+			return token{quoted, value.String(), start}
+		default:
+			value.WriteByte(c)
+			l.pos++
+		}
+	}
+
+	return token{unterminated, "", start}
+}
@@ -0,0 +1,74 @@
+package aip
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/javiorfo/gormen"
+	"github.com/javiorfo/gormen/filter"
+	"github.com/javiorfo/gormen/pagination/sort"
+)
+
+// ParseOrderBy converts an AIP-132 order_by, such as "username desc, id", into sort orders
+// for pagination.WithOrder. Fields are looked up by public name in the registry and sorted
+// in ascending order unless followed by desc; asc is accepted too.
+// The joins the fields require are returned in a gormen.Where without conditions, to be merged
+// into the Where of the query.
+// Any problem is reported as a *filter.SyntaxError with its position in the input.
+// A blank input returns no orders.
+func ParseOrderBy(input string, registry filter.Registry) ([]sort.Order, gormen.Where, error) {
+	var orders []sort.Order
+	var joins []gormen.Join
+	if strings.TrimSpace(input) == "" {
+		return orders, gormen.Where{}, nil
+	}
+
+	start := 0
+	for _, part := range strings.Split(input, ",") {
+		words, positions := fields(part, start)
+		start += len(part) + 1
+
+		if len(words) == 0 {
+			return nil, gormen.Where{}, &filter.SyntaxError{Position: start - 1, Message: "expected field name"}
+		}
+
+		field, err := registry.Lookup(words[0])
+		if err != nil {
+			return nil, gormen.Where{}, &filter.SyntaxError{Position: positions[0], Message: err.Error()}
+		}
+
+		direction := sort.Ascending
+		switch {
+		case len(words) > 2:
+			return nil, gormen.Where{}, &filter.SyntaxError{Position: positions[2], Message: "expected ','"}
+		case len(words) == 2 && words[1] == sort.Descending:
+			direction = sort.Descending
+		case len(words) == 2 && words[1] != sort.Ascending:
+			return nil, gormen.Where{}, &filter.SyntaxError{Position: positions[1], Message: "expected 'asc' or 'desc'"}
+		}
+
+		if field.Join != "" && !slices.Contains(joins, field.Join) {
+			joins = append(joins, field.Join)
+		}
+		orders = append(orders, sort.NewOrder(field.Column, direction))
+	}
+
+	return orders, gormen.NewWhere().WithJoin(joins...).Build(), nil
+}
+
+// fields splits the text around spaces, returning the words with their positions in the input
+// given the position where the text starts.
+func fields(text string, start int) ([]string, []int) {
+	var words []string
+	var positions []int
+
+	offset := 0
+	for _, word := range strings.Fields(text) {
+		i := strings.Index(text[offset:], word) + offset
+		words = append(words, word)
+		positions = append(positions, start+i)
+		offset = i + len(word)
+	}
+
+	return words, positions
+}
@@ -0,0 +1,88 @@
+package filter
+
+import (
+	"fmt"
+
+	"github.com/javiorfo/gormen/where"
+)
+
+// Operator is a comparison supported by the query languages.
+type Operator int
+
+const (
+	Equal Operator = iota
+	NotEqual
+	LessThan
+	LessOrEqual
+	GreaterThan
+	GreaterOrEqual
+	In
+	NotIn
+	// Like matches strings against a pattern where * matches any sequence of characters
+	Like
+	// NotLike excludes strings matching a pattern where * matches any sequence of characters
+	NotLike
+	// IsNull takes a single bool value: true matches null values, false non null ones
+	IsNull
+)
+
+// Condition constructs the where.Condition comparing the field column with the values,
+// converted to the kind of the field. In and NotIn take any number of values, other operators exactly one.
+func (f Field) Condition(operator Operator, values ...any) (where.Condition, error) {
+	if operator != In && operator != NotIn && len(values) != 1 {
+		return nil, fmt.Errorf("operator expects a single value, got %d", len(values))
+	}
+
+	if operator == IsNull {
+		isNull, err := Field{Kind: Bool}.Value(values[0])
+		if err != nil {
+			return nil, err
+		}
+		if isNull.(bool) {
+			return where.IsNull(f.Column), nil
+		}
+		return where.IsNotNull(f.Column), nil
+	}
+
+	if operator == Like || operator == NotLike {
+		if f.Kind != String {
+			return nil, fmt.Errorf("patterns can only be matched against string fields, not %s", f.Kind)
+		}
+		pattern, ok := values[0].(string)
+		if !ok {
+			return nil, fmt.Errorf("'%v' is not a valid pattern", values[0])
+		}
+		if operator == NotLike {
+			return where.Not(where.Glob(f.Column, pattern)), nil
+		}
+		return where.Glob(f.Column, pattern), nil
+	}
+
+	converted := make([]any, len(values))
+	for i, v := range values {
+		value, err := f.Value(v)
+		if err != nil {
+			return nil, err
+		}
+		converted[i] = value
+	}
+
+	switch operator {
+	case NotEqual:
+		return where.NotEqual(f.Column, converted[0]), nil
+	case LessThan:
+		return where.LessThan(f.Column, converted[0]), nil
+	case LessOrEqual:
+		return where.LessOrEqual(f.Column, converted[0]), nil
+	case GreaterThan:
+		return where.GreaterThan(f.Column, converted[0]), nil
+	case GreaterOrEqual:
+		return where.GreaterOrEqual(f.Column, converted[0]), nil
+	case In:
+		return where.In(f.Column, converted), nil
+	case NotIn:
+		return where.NotIn(f.Column, converted), nil
+	default:
+		return where.Equal(f.Column, converted[0]), nil
+	}
+}
@@ -0,0 +1,20 @@
+// Package filter holds what the query language parsers of its subpackages share:
+// the registry of fields clients can filter and sort by, the conversion of their values
+// and the errors reported with the position of the problem in the query.
+package filter
+
+import "fmt"
+
+// SyntaxError reports a problem found in a query, at the zero-based byte offset of the input
+// where it starts, so it can be returned to API clients as is.
+type SyntaxError struct {
+	// Offset of the problem in the query
+	Position int
+	// Description of the problem
+	Message string
+}
+
+// Error satisfies the error interface.
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("%s at position %d", e.Message, e.Position)
+}
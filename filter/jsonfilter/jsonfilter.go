@@ -0,0 +1,434 @@
+// Package jsonfilter decodes Mongo-like JSON filters, such as
+// {"enable": true, "$or": [{"username": {"$like": "b%"}}, {"id": {"$in": [1, 2]}}]},
+// into a gormen.Where, and encodes them back to JSON so saved filters can round-trip.
+package jsonfilter
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+
+	"github.com/javiorfo/gormen"
+	"github.com/javiorfo/gormen/filter"
+	"github.com/javiorfo/gormen/where"
+)
+
+// operators maps the comparison operators of a field to filter operators;
+// $like and $exists are handled apart.
+var operators = map[string]filter.Operator{
+	"$eq":  filter.Equal,
+	"$ne":  filter.NotEqual,
+	"$lt":  filter.LessThan,
+	"$lte": filter.LessOrEqual,
+	"$gt":  filter.GreaterThan,
+	"$gte": filter.GreaterOrEqual,
+	"$in":  filter.In,
+	"$nin": filter.NotIn,
+}
+
+// Filter is a JSON filter document: an object whose entries must all match.
+// An entry is either a field with a value to be equal to, or an object of comparison operators
+// ($eq, $ne, $lt, $lte, $gt, $gte, $in, $nin, $like with a SQL LIKE pattern and $exists with a bool),
+// or one of the logical operators $and and $or, with an array of filters, and $not, with a filter.
+// It keeps the entries in the order of the JSON document and encodes back to the same JSON, compacted.
+type Filter struct {
+	entries []entry
+}
+
+// entry is a field or logical operator of a filter, with its position in the JSON document.
+type entry struct {
+	position int
+	key      string
+	// Comparisons of a field; a single one without operator for the value shorthand
+	comparisons []comparison
+	// Filters of a logical operator
+	filters []Filter
+}
+
+// comparison is a comparison operator of a field and its JSON value, with their position in the JSON document.
+type comparison struct {
+	position int
+	operator string
+	value    json.RawMessage
+}
+
+// Parse decodes the JSON filter and converts it into a gormen.Where, see Filter.Where.
+func Parse(data []byte, registry filter.Registry) (gormen.Where, error) {
+	var f Filter
+	if err := f.UnmarshalJSON(data); err != nil {
+		return gormen.Where{}, err
+	}
+	return f.Where(registry)
+}
+
+// UnmarshalJSON decodes a JSON filter document, checking its structure and operators.
+// Problems are reported as a *filter.SyntaxError with its position in the data.
+// Satisfies json.Unmarshaler interface
+func (f *Filter) UnmarshalJSON(data []byte) error {
+	d := &decoder{json.NewDecoder(bytes.NewReader(data)), data}
+	d.UseNumber()
+
+	decoded, err := d.filter()
+	if err != nil {
+		return err
+	}
+
+	position := d.position()
+	if _, err := d.Token(); err != io.EOF {
+		return &filter.SyntaxError{Position: position, Message: "unexpected data after filter"}
+	}
+
+	*f = decoded
+	return nil
+}
+
+// MarshalJSON encodes the filter as a compact JSON document, with its entries in order.
+// Satisfies json.Marshaler interface
+func (f Filter) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+
+	for i, e := range f.entries {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		key, _ := json.Marshal(e.key)
+		buf.Write(key)
+		buf.WriteByte(':')
+
+		switch {
+		case e.key == "$not":
+			nested, err := e.filters[0].MarshalJSON()
+			if err != nil {
+				return nil, err
+			}
+			buf.Write(nested)
+		case e.filters != nil:
+			buf.WriteByte('[')
+			for j, nested := range e.filters {
+				if j > 0 {
+					buf.WriteByte(',')
+				}
+				encoded, err := nested.MarshalJSON()
+				if err != nil {
+					return nil, err
+				}
+				buf.Write(encoded)
+			}
+			buf.WriteByte(']')
+		case len(e.comparisons) == 1 && e.comparisons[0].operator == "":
+			if err := json.Compact(&buf, e.comparisons[0].value); err != nil {
+				return nil, err
+			}
+		default:
+			buf.WriteByte('{')
+			for j, c := range e.comparisons {
+				if j > 0 {
+					buf.WriteByte(',')
+				}
+				operator, _ := json.Marshal(c.operator)
+				buf.Write(operator)
+				buf.WriteByte(':')
+				if err := json.Compact(&buf, c.value); err != nil {
+					return nil, err
+				}
+			}
+			buf.WriteByte('}')
+		}
+	}
+
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// Where converts the filter into a gormen.Where with the joins of the fields it references.
+// Fields are looked up by public name in the registry, and their values converted to the field kind.
+// Problems are reported as a *filter.SyntaxError with the position in the decoded JSON document.
+// An empty filter returns an empty Where.
+func (f Filter) Where(registry filter.Registry) (gormen.Where, error) {
+	c := &converter{registry: registry}
+
+	conditions, err := c.conditions(f)
+	if err != nil {
+		return gormen.Where{}, err
+	}
+
+	return gormen.NewWhere(conditions...).WithJoin(c.joins...).Build(), nil
+}
+
+// converter converts filters into conditions, collecting the joins of the fields found.
+type converter struct {
+	registry filter.Registry
+	joins    []gormen.Join
+}
+
+// conditions returns the conditions of every entry of the filter.
+func (c *converter) conditions(f Filter) ([]where.Condition, error) {
+	var conditions []where.Condition
+
+	for _, e := range f.entries {
+		switch e.key {
+		case "$and", "$or":
+			var group []where.Condition
+			for _, nested := range e.filters {
+				condition, err := c.condition(nested)
+				if err != nil {
+					return nil, err
+				}
+				group = append(group, condition)
+			}
+
+			if e.key == "$or" {
+				conditions = append(conditions, where.AnyOf(group...))
+			} else {
+				conditions = append(conditions, where.AllOf(group...))
+			}
+		case "$not":
+			condition, err := c.condition(e.filters[0])
+			if err != nil {
+				return nil, err
+			}
+			conditions = append(conditions, where.Not(condition))
+		default:
+			field, err := c.registry.Lookup(e.key)
+			if err != nil {
+				return nil, &filter.SyntaxError{Position: e.position, Message: err.Error()}
+			}
+
+			for _, comparison := range e.comparisons {
+				condition, err := comparison.condition(field)
+				if err != nil {
+					return nil, &filter.SyntaxError{Position: comparison.position, Message: err.Error()}
+				}
+				conditions = append(conditions, condition)
+			}
+
+			if field.Join != "" && !slices.Contains(c.joins, field.Join) {
+				c.joins = append(c.joins, field.Join)
+			}
+		}
+	}
+
+	return conditions, nil
+}
+
+// condition returns the conditions of the filter as a single one.
+func (c *converter) condition(f Filter) (where.Condition, error) {
+	conditions, err := c.conditions(f)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(conditions) == 1 {
+		return conditions[0], nil
+	}
+	return where.AllOf(conditions...), nil
+}
+
+// condition converts the comparison of the field into a where.Condition.
+func (c comparison) condition(field filter.Field) (where.Condition, error) {
+	var value any
+	decoder := json.NewDecoder(bytes.NewReader(c.value))
+	decoder.UseNumber()
+	if err := decoder.Decode(&value); err != nil {
+		return nil, err
+	}
+
+	switch c.operator {
+	case "", "$eq", "$ne":
+		if value == nil {
+			return field.Condition(filter.IsNull, c.operator != "$ne")
+		}
+	case "$exists":
+		exists, ok := value.(bool)
+		if !ok {
+			return nil, errors.New("'$exists' takes true or false")
+		}
+		return field.Condition(filter.IsNull, !exists)
+	case "$like":
+		pattern, ok := value.(string)
+		if !ok || field.Kind != filter.String {
+			return nil, errors.New("'$like' takes a pattern and can only be used with string fields")
+		}
+		return where.Like(field.Column, pattern), nil
+	case "$in", "$nin":
+		values, ok := value.([]any)
+		if !ok {
+			return nil, fmt.Errorf("'%s' takes an array of values", c.operator)
+		}
+		for i := range values {
+			if values[i] == nil {
+				return nil, fmt.Errorf("'%s' does not take null values", c.operator)
+			}
+			values[i] = number(values[i], field)
+		}
+		return field.Condition(operators[c.operator], values...)
+	}
+
+	if value == nil {
+		return nil, fmt.Errorf("'%s' does not take null, only '$eq' and '$ne' do", c.operator)
+	}
+	if _, ok := value.([]any); ok {
+		return nil, errors.New("arrays can only be compared with '$in' and '$nin'")
+	}
+	if _, ok := value.(map[string]any); ok {
+		return nil, errors.New("objects can not be compared")
+	}
+
+	return field.Condition(operators[c.operator], number(value, field))
+}
+
+// number returns JSON numbers as strings for numeric fields to be parsed exactly,
+// keeping them as json.Number, which is rejected, for other kinds.
+func number(value any, field filter.Field) any {
+	if n, ok := value.(json.Number); ok && (field.Kind == filter.Int || field.Kind == filter.Float) {
+		return n.String()
+	}
+	return value
+}
+
+// decoder reads a JSON filter document token by token, keeping track of positions.
+type decoder struct {
+	*json.Decoder
+	data []byte
+}
+
+// position returns the offset of the next token, after spaces and separators.
+func (d *decoder) position() int {
+	position := int(d.InputOffset())
+	for position < len(d.data) && bytes.IndexByte([]byte(" \t\r\n,:"), d.data[position]) >= 0 {
+		position++
+	}
+	return position
+}
+
+// filter decodes a JSON object into a Filter.
+func (d *decoder) filter() (Filter, error) {
+	if err := d.delimiter('{', "expected filter object"); err != nil {
+		return Filter{}, err
+	}
+
+	var f Filter
+	for d.More() {
+		position := d.position()
+		token, err := d.Token()
+		if err != nil {
+			return Filter{}, d.syntaxError(err)
+		}
+		key := token.(string)
+
+		e := entry{position: position, key: key}
+		switch {
+		case key == "$and" || key == "$or":
+			if err := d.delimiter('[', "expected array of filters"); err != nil {
+				return Filter{}, err
+			}
+			e.filters = []Filter{}
+			for d.More() {
+				nested, err := d.filter()
+				if err != nil {
+					return Filter{}, err
+				}
+				e.filters = append(e.filters, nested)
+			}
+			if err := d.delimiter(']', "expected ']'"); err != nil {
+				return Filter{}, err
+			}
+		case key == "$not":
+			nested, err := d.filter()
+			if err != nil {
+				return Filter{}, err
+			}
+			e.filters = []Filter{nested}
+		case len(key) > 0 && key[0] == '$':
+			return Filter{}, &filter.SyntaxError{Position: position, Message: fmt.Sprintf("unsupported operator '%s'", key)}
+		default:
+			if e.comparisons, err = d.comparisons(); err != nil {
+				return Filter{}, err
+			}
+		}
+
+		f.entries = append(f.entries, e)
+	}
+
+	if err := d.delimiter('}', "expected '}'"); err != nil {
+		return Filter{}, err
+	}
+
+	return f, nil
+}
+
+// comparisons decodes the value of a field: an object of comparison operators or a value to be equal to.
+func (d *decoder) comparisons() ([]comparison, error) {
+	position := d.position()
+	if position >= len(d.data) || d.data[position] != '{' {
+		var value json.RawMessage
+		if err := d.Decode(&value); err != nil {
+			return nil, d.syntaxError(err)
+		}
+		return []comparison{{position, "", value}}, nil
+	}
+
+	if _, err := d.Token(); err != nil {
+		return nil, d.syntaxError(err)
+	}
+
+	var comparisons []comparison
+	for d.More() {
+		position := d.position()
+		token, err := d.Token()
+		if err != nil {
+			return nil, d.syntaxError(err)
+		}
+
+		operator := token.(string)
+		if _, ok := operators[operator]; !ok && operator != "$like" && operator != "$exists" {
+			return nil, &filter.SyntaxError{Position: position, Message: fmt.Sprintf("unsupported operator '%s'", operator)}
+		}
+
+		var value json.RawMessage
+		if err := d.Decode(&value); err != nil {
+			return nil, d.syntaxError(err)
+		}
+		comparisons = append(comparisons, comparison{position, operator, value})
+	}
+
+	if len(comparisons) == 0 {
+		return nil, &filter.SyntaxError{Position: position, Message: "expected comparison operator"}
+	}
+
+	if _, err := d.Token(); err != nil {
+		return nil, d.syntaxError(err)
+	}
+
+	return comparisons, nil
+}
+
+// delimiter consumes the expected delimiter or reports the message at its position.
+func (d *decoder) delimiter(expected json.Delim, message string) error {
+	position := d.position()
+	token, err := d.Token()
+	if err != nil {
+		return d.syntaxError(err)
+	}
+
+	if delim, ok := token.(json.Delim); !ok || delim != expected {
+		return &filter.SyntaxError{Position: position, Message: message}
+	}
+	return nil
+}
+
+// syntaxError converts a JSON decoding error into a SyntaxError.
+func (d *decoder) syntaxError(err error) error {
+	var jsonErr *json.SyntaxError
+	if errors.As(err, &jsonErr) {
+		return &filter.SyntaxError{Position: int(jsonErr.Offset), Message: jsonErr.Error()}
+	}
+	if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return &filter.SyntaxError{Position: len(d.data), Message: "unexpected end of filter"}
+	}
+	return &filter.SyntaxError{Position: d.position(), Message: err.Error()}
+}
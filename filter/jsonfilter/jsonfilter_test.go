@@ -0,0 +1,116 @@
+package jsonfilter
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/javiorfo/gormen/filter"
+	"github.com/javiorfo/gormen/internal/testutils"
+)
+
+var registry = filter.Registry{
+	"id":           {Column: "id", Kind: filter.Int},
+	"username":     {Column: "username", Kind: filter.String},
+	"person.email": {Column: "Person.email", Kind: filter.String, Join: "Person"},
+	"created":      {Column: "created_at", Kind: filter.Time},
+}
+
+func TestParse(t *testing.T) {
+	db := testutils.SetupDryRunDB("sqlite")
+
+	tests := []struct {
+		name  string
+		input string
+		sql   string
+	}{
+		{"Empty", "{}", "SELECT * FROM `users`"},
+		{"Nested groups", `{"username": "jdoe", "$or": [{"username": {"$like": "b%"}}, {"id": {"$in": [1, 2]}, "$not": {"id": 3}}]}`,
+			"SELECT * FROM `users` WHERE `users`.`username` = \"jdoe\" AND (`users`.`username` like \"b%\" OR (`users`.`id` in (1,2) AND NOT (`users`.`id` = 3)))"},
+		{"Operators", `{"id": {"$gte": 1, "$lt": 10, "$nin": [5]}, "username": {"$ne": null, "$exists": true}}`,
+			"SELECT * FROM `users` WHERE `users`.`id` >= 1 AND `users`.`id` < 10 AND `users`.`id` not in (5) AND `users`.`username` is not null AND `users`.`username` is not null"},
+		{"Joins", `{"$and": [{"person.email": null}, {"username": {"$eq": "x"}}]}`,
+			"SELECT `users`.`id`,`users`.`username`,`users`.`password`,`users`.`person_id`,`Person`.`id` AS `Person__id`,`Person`.`name` AS `Person__name`,`Person`.`email` AS `Person__email` " +
+				"FROM `users` LEFT JOIN `persons` `Person` ON `users`.`person_id` = `Person`.`id` WHERE (`Person`.`email` is null AND `users`.`username` = \"x\")"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w, err := Parse([]byte(tt.input), registry)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			statement, err := w.ToSQL(db, &testutils.UserDB{})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if sql := statement.String(); sql != tt.sql {
+				t.Errorf("expected %q, got %q", tt.sql, sql)
+			}
+		})
+	}
+}
+
+func TestFilter_RoundTrip(t *testing.T) {
+	input := `{ "username": {"$like": "b%", "$ne": "bob"},
+		"$or": [ {"id": 1}, {"$not": {"id": {"$in": [2, 3]}}} ], "id": 12345678901234567890 }`
+
+	var request struct {
+		Filter Filter `json:"filter"`
+	}
+	if err := json.Unmarshal([]byte(`{"filter": `+input+`}`), &request); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	encoded, err := json.Marshal(request)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := `{"filter":{"username":{"$like":"b%","$ne":"bob"},"$or":[{"id":1},{"$not":{"id":{"$in":[2,3]}}}],"id":12345678901234567890}}`
+	if string(encoded) != expected {
+		t.Errorf("expected %s, got %s", expected, encoded)
+	}
+}
+
+func TestParse_Errors(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		position int
+	}{
+		{"Not an object", `[1]`, 0},
+		{"Invalid JSON", `{"id": 1,}`, 9},
+		{"Unknown field", `{"id": 1, "password": "x"}`, 10},
+		{"Unsupported logical operator", `{"$nor": []}`, 1},
+		{"Unsupported comparison operator", `{"id": {"$regex": "x"}}`, 8},
+		{"Empty comparison", `{"id": {}}`, 7},
+		{"Invalid value", `{"id": {"$gt": "one"}}`, 8},
+		{"String for number field", `{"username": 1}`, 13},
+		{"Array without in", `{"id": [1, 2]}`, 7},
+		{"Or without array", `{"$or": {"id": 1}}`, 8},
+		{"Like on number field", `{"id": {"$like": "1%"}}`, 8},
+		{"Trailing data", `{"id": 1} {}`, 10},
+		{"Unexpected end", `{"id": `, 7},
+		{"Null with gt", `{"created": {"$gt": null}}`, 13},
+		{"Null in in", `{"created": {"$in": [null]}}`, 13},
+		{"Null with like", `{"username": {"$like": null}}`, 14},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse([]byte(tt.input), registry)
+
+			var syntaxErr *filter.SyntaxError
+			if !errors.As(err, &syntaxErr) {
+				t.Fatalf("expected a syntax error, got %v", err)
+			}
+
+			if syntaxErr.Position != tt.position {
+				t.Errorf("expected position %d, got %d (%v)", tt.position, syntaxErr.Position, err)
+			}
+		})
+	}
+}
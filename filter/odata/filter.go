@@ -0,0 +1,382 @@
+package odata
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"unicode"
+
+	"github.com/javiorfo/gormen"
+	"github.com/javiorfo/gormen/filter"
+	"github.com/javiorfo/gormen/where"
+)
+
+// comparisons maps the OData comparison operators to filter operators.
+var comparisons = map[string]filter.Operator{
+	"eq": filter.Equal,
+	"ne": filter.NotEqual,
+	"lt": filter.LessThan,
+	"le": filter.LessOrEqual,
+	"gt": filter.GreaterThan,
+	"ge": filter.GreaterOrEqual,
+}
+
+// functions maps the supported OData string functions to where conditions.
+var functions = map[string]func(where.ColumnName, string) where.Condition{
+	"contains":   func(name where.ColumnName, value string) where.Condition { return where.Contains(name, value) },
+	"startswith": func(name where.ColumnName, value string) where.Condition { return where.StartsWith(name, value) },
+	"endswith":   func(name where.ColumnName, value string) where.Condition { return where.EndsWith(name, value) },
+}
+
+// parseFilter converts a $filter expression into a gormen.Where.
+// It supports and, or, not, parentheses, the eq, ne, lt, le, gt, ge and in operators
+// and the contains, startswith and endswith functions. Strings are quoted with ' and escape it doubling it;
+// null, true, false, numbers and dates are written as is.
+func parseFilter(input string, registry filter.Registry) (gormen.Where, error) {
+	p := &parser{input: input, registry: registry}
+
+	if p.peek().kind == end {
+		return gormen.Where{}, nil
+	}
+
+	condition, err := p.or()
+	if err != nil {
+		return gormen.Where{}, err
+	}
+
+	if t := p.peek(); t.kind != end {
+		return gormen.Where{}, t.errorf("unexpected '%s'", t.text)
+	}
+
+	return gormen.NewWhere(condition).WithJoin(p.joins...).Build(), nil
+}
+
+// parser is a recursive descent parser over the tokens of the input,
+// collecting the joins of the fields found.
+type parser struct {
+	input    string
+	pos      int
+	peeked   *token
+	registry filter.Registry
+	joins    []gormen.Join
+}
+
+// or parses expressions separated by or.
+func (p *parser) or() (where.Condition, error) {
+	var conditions []where.Condition
+	for {
+		c, err := p.and()
+		if err != nil {
+			return nil, err
+		}
+		conditions = append(conditions, c)
+
+		if !p.peek().is("or") {
+			break
+		}
+		p.next()
+	}
+
+	if len(conditions) == 1 {
+		return conditions[0], nil
+	}
+	return where.AnyOf(conditions...), nil
+}
+
+// and parses expressions separated by and.
+func (p *parser) and() (where.Condition, error) {
+	var conditions []where.Condition
+	for {
+		c, err := p.unary()
+		if err != nil {
+			return nil, err
+		}
+		conditions = append(conditions, c)
+
+		if !p.peek().is("and") {
+			break
+		}
+		p.next()
+	}
+
+	if len(conditions) == 1 {
+		return conditions[0], nil
+	}
+	return where.AllOf(conditions...), nil
+}
+
+// unary parses an expression optionally negated with not.
+func (p *parser) unary() (where.Condition, error) {
+	if p.peek().is("not") {
+		p.next()
+		c, err := p.unary()
+		if err != nil {
+			return nil, err
+		}
+		return where.Not(c), nil
+	}
+
+	return p.primary()
+}
+
+// primary parses a parenthesized expression, a function call or a comparison.
+func (p *parser) primary() (where.Condition, error) {
+	t := p.next()
+
+	switch t.kind {
+	case opening:
+		c, err := p.or()
+		if err != nil {
+			return nil, err
+		}
+		if t := p.next(); t.kind != closing {
+			return nil, t.errorf("expected ')'")
+		}
+		return c, nil
+	case word:
+		if p.peek().kind == opening {
+			return p.function(t)
+		}
+		return p.comparison(t)
+	case end:
+		return nil, t.errorf("expected expression")
+	case unterminated:
+		return nil, t.errorf("unterminated string")
+	default:
+		return nil, t.errorf("unexpected '%s'", t.text)
+	}
+}
+
+// function parses the arguments of a supported function: a field and a string.
+func (p *parser) function(name token) (where.Condition, error) {
+	function, ok := functions[name.text]
+	if !ok {
+		return nil, name.errorf("unsupported function '%s'", name.text)
+	}
+	p.next()
+
+	t := p.next()
+	if t.kind != word {
+		return nil, t.errorf("expected field name")
+	}
+	field, err := p.field(t)
+	if err != nil {
+		return nil, err
+	}
+	if field.Kind != filter.String {
+		return nil, t.errorf("'%s' can only be used with string fields", name.text)
+	}
+
+	if t := p.next(); t.kind != comma {
+		return nil, t.errorf("expected ','")
+	}
+
+	value := p.next()
+	if value.kind != str {
+		return nil, value.errorf("expected string")
+	}
+
+	if t := p.next(); t.kind != closing {
+		return nil, t.errorf("expected ')'")
+	}
+
+	return function(field.Column, value.text), nil
+}
+
+// comparison parses the operator and value following a field name.
+func (p *parser) comparison(name token) (where.Condition, error) {
+	field, err := p.field(name)
+	if err != nil {
+		return nil, err
+	}
+
+	operator := p.next()
+	if operator.kind != word {
+		return nil, operator.errorf("expected operator after '%s'", name.text)
+	}
+
+	if operator.is("in") {
+		return p.in(field)
+	}
+
+	op, ok := comparisons[operator.text]
+	if !ok {
+		return nil, operator.errorf("unsupported operator '%s'", operator.text)
+	}
+
+	value := p.next()
+	if value.kind == word && value.text == "null" && (op == filter.Equal || op == filter.NotEqual) {
+		return field.Condition(filter.IsNull, op == filter.Equal)
+	}
+
+	v, err := literal(value, field)
+	if err != nil {
+		return nil, err
+	}
+
+	condition, err := field.Condition(op, v)
+	if err != nil {
+		return nil, value.errorf("%s", err)
+	}
+	return condition, nil
+}
+
+// in parses the parenthesized list of values of the in operator.
+func (p *parser) in(field filter.Field) (where.Condition, error) {
+	if t := p.next(); t.kind != opening {
+		return nil, t.errorf("expected '('")
+	}
+
+	start := p.peek()
+	var values []any
+	for {
+		value := p.next()
+		v, err := literal(value, field)
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, v)
+
+		t := p.next()
+		if t.kind == closing {
+			break
+		}
+		if t.kind != comma {
+			return nil, t.errorf("expected ',' or ')'")
+		}
+	}
+
+	condition, err := field.Condition(filter.In, values...)
+	if err != nil {
+		return nil, start.errorf("%s", err)
+	}
+	return condition, nil
+}
+
+// field looks up the field named by the token, adding its join.
+func (p *parser) field(name token) (filter.Field, error) {
+	field, err := p.registry.Lookup(name.text)
+	if err != nil {
+		return filter.Field{}, name.errorf("%s", err)
+	}
+
+	if field.Join != "" && !slices.Contains(p.joins, field.Join) {
+		p.joins = append(p.joins, field.Join)
+	}
+	return field, nil
+}
+
+// literal returns the value of a string or word token compared with the field.
+// Values of string fields must be quoted.
+func literal(t token, field filter.Field) (string, error) {
+	switch {
+	case t.kind == word && field.Kind == filter.String:
+		return "", t.errorf("expected string")
+	case t.kind == str || t.kind == word:
+		return t.text, nil
+	case t.kind == unterminated:
+		return "", t.errorf("unterminated string")
+	default:
+		return "", t.errorf("expected value")
+	}
+}
+
+// kind of a token.
+type kind int
+
+const (
+	end kind = iota
+	word
+	str
+	opening
+	closing
+	comma
+	unterminated
+)
+
+// token is a lexical unit of a $filter expression, with its position in the input.
+type token struct {
+	kind     kind
+	text     string
+	position int
+}
+
+// is reports whether the token is the given keyword.
+func (t token) is(keyword string) bool {
+	return t.kind == word && t.text == keyword
+}
+
+// errorf returns a SyntaxError at the position of the token.
+func (t token) errorf(format string, args ...any) error {
+	return &filter.SyntaxError{Position: t.position, Message: fmt.Sprintf(format, args...)}
+}
+
+// peek returns the next token without consuming it.
+func (p *parser) peek() token {
+	if p.peeked == nil {
+		t := p.scan()
+		p.peeked = &t
+	}
+	return *p.peeked
+}
+
+// next consumes and returns the next token.
+func (p *parser) next() token {
+	t := p.peek()
+	p.peeked = nil
+	return t
+}
+
+// scan reads the token at the current position.
+func (p *parser) scan() token {
+	for p.pos < len(p.input) && unicode.IsSpace(rune(p.input[p.pos])) {
+		p.pos++
+	}
+
+	start := p.pos
+	if start == len(p.input) {
+		return token{end, "", start}
+	}
+
+	switch p.input[start] {
+	case '(':
+		p.pos++
+		return token{opening, "(", start}
+	case ')':
+		p.pos++
+		return token{closing, ")", start}
+	case ',':
+		p.pos++
+		return token{comma, ",", start}
+	case '\'':
+		return p.string()
+	}
+
+	for p.pos < len(p.input) && !unicode.IsSpace(rune(p.input[p.pos])) && !strings.ContainsRune("(),'", rune(p.input[p.pos])) {
+		p.pos++
+	}
+	return token{word, p.input[start:p.pos], start}
+}
+
+// string reads a string quoted with single quotes, where two single quotes stand for one.
+func (p *parser) string() token {
+	start := p.pos
+	p.pos++
+
+	var value strings.Builder
+	for p.pos < len(p.input) {
+		if p.input[p.pos] == '\'' {
+			if p.pos+1 < len(p.input) && p.input[p.pos+1] == '\'' {
+				value.WriteByte('\'')
+				p.pos += 2
+				continue
+			}
+			p.pos++
+			return token{str, value.String(), start}
+		}
+		value.WriteByte(p.input[p.pos])
+		p.pos++
+	}
+
+	return token{unterminated, "", start}
+}
@@ -0,0 +1,208 @@
+// Package odata parses the OData query options $filter, $orderby, $top, $skip, $select and $count
+// into a gormen.Where, a pagination.Pageable and the selected columns.
+package odata
+
+import (
+	"fmt"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/javiorfo/gormen"
+	"github.com/javiorfo/gormen/filter"
+	"github.com/javiorfo/gormen/pagination"
+	"github.com/javiorfo/gormen/pagination/sort"
+	"github.com/javiorfo/gormen/where"
+	"gorm.io/gorm"
+)
+
+// Query holds the parsed OData query options.
+type Query struct {
+	// Conditions of $filter, with the joins of the fields referenced
+	Where gormen.Where
+	// Offset and limit of $skip and $top, with the orders of $orderby
+	Pageable pagination.Pageable
+	// Columns of $select, empty to select all of them
+	Select []where.ColumnName
+	// Whether $count=true asked for the total number of matching records
+	Count bool
+}
+
+// Parse converts the OData query options of the values into a Query.
+// Fields are looked up by public name in the registry, paths using / such as person/email.
+// The joins of the fields referenced by $filter, $orderby and $select are added to Query.Where.
+// $top must not exceed maxTop, which is also the number of records returned without $top.
+// Unsupported query options, such as $expand or $search, and unsupported functions are rejected;
+// syntax problems are reported as a *filter.SyntaxError, wrapped with the name of the option.
+// Options are parsed in name order, so the same values always report the same error.
+// Values without the $ prefix are ignored. maxTop must be greater than 0.
+func Parse(values url.Values, registry filter.Registry, maxTop int) (Query, error) {
+	if maxTop <= 0 {
+		return Query{}, fmt.Errorf("maxTop must be greater than 0, got %d", maxTop)
+	}
+
+	query := Query{Where: gormen.Where{}}
+	page := &pageable{top: maxTop}
+
+	var joins []gormen.Join
+	var options []string
+	for option := range values {
+		if strings.HasPrefix(option, "$") {
+			options = append(options, option)
+		}
+	}
+	slices.Sort(options)
+
+	for _, option := range options {
+		v := values[option]
+		if len(v) != 1 {
+			return Query{}, fmt.Errorf("%s: must be given once", option)
+		}
+
+		var err error
+		switch option {
+		case "$filter":
+			query.Where, err = parseFilter(v[0], registry)
+		case "$orderby":
+			page.orders, err = parseOrderBy(v[0], registry, &joins)
+		case "$top":
+			page.top, err = parseCount(v[0])
+			if err == nil && page.top > maxTop {
+				err = fmt.Errorf("must not be greater than %d", maxTop)
+			}
+		case "$skip":
+			page.skip, err = parseCount(v[0])
+		case "$select":
+			query.Select, err = parseSelect(v[0], registry, &joins)
+		case "$count":
+			query.Count, err = strconv.ParseBool(v[0])
+			if err != nil {
+				err = fmt.Errorf("'%s' is not true or false", v[0])
+			}
+		default:
+			err = fmt.Errorf("unsupported query option")
+		}
+
+		if err != nil {
+			return Query{}, fmt.Errorf("%s: %w", option, err)
+		}
+	}
+
+	query.Where.Merge(gormen.NewWhere().WithJoin(joins...).Build())
+	query.Pageable = page
+	return query, nil
+}
+
+// parseCount parses the non-negative integer of $top and $skip.
+func parseCount(value string) (int, error) {
+	count, err := strconv.Atoi(value)
+	if err != nil || count < 0 {
+		return 0, fmt.Errorf("'%s' is not a non-negative integer", value)
+	}
+	return count, nil
+}
+
+// parseSelect parses the comma-separated fields of $select into their columns,
+// adding the joins they require.
+func parseSelect(value string, registry filter.Registry, joins *[]gormen.Join) ([]where.ColumnName, error) {
+	var columns []where.ColumnName
+	position := 0
+	for _, name := range strings.Split(value, ",") {
+		field, err := registry.Lookup(strings.TrimSpace(name))
+		if err != nil {
+			return nil, &filter.SyntaxError{Position: position + strings.Index(name, strings.TrimSpace(name)), Message: err.Error()}
+		}
+		addJoin(joins, field)
+		columns = append(columns, field.Column)
+		position += len(name) + 1
+	}
+	return columns, nil
+}
+
+// parseOrderBy parses the comma-separated fields of $orderby, each optionally followed by asc or desc,
+// adding the joins they require.
+func parseOrderBy(value string, registry filter.Registry, joins *[]gormen.Join) ([]sort.Order, error) {
+	var orders []sort.Order
+	position := 0
+	for _, item := range strings.Split(value, ",") {
+		words := strings.Fields(item)
+		start := position + len(item) - len(strings.TrimLeft(item, " "))
+		position += len(item) + 1
+
+		if len(words) == 0 {
+			return nil, &filter.SyntaxError{Position: start, Message: "expected field name"}
+		}
+
+		field, err := registry.Lookup(words[0])
+		if err != nil {
+			return nil, &filter.SyntaxError{Position: start, Message: err.Error()}
+		}
+
+		direction := sort.Ascending
+		if len(words) > 1 {
+			switch {
+			case len(words) == 2 && words[1] == sort.Descending:
+				direction = sort.Descending
+			case len(words) == 2 && words[1] == sort.Ascending:
+			default:
+				return nil, &filter.SyntaxError{Position: start + len(words[0]), Message: "expected 'asc' or 'desc'"}
+			}
+		}
+
+		addJoin(joins, field)
+		orders = append(orders, sort.NewOrder(field.Column, direction))
+	}
+	return orders, nil
+}
+
+// addJoin adds the join of the field, if any, to the joins not already including it.
+func addJoin(joins *[]gormen.Join, field filter.Field) {
+	if field.Join != "" && !slices.Contains(*joins, field.Join) {
+		*joins = append(*joins, field.Join)
+	}
+}
+
+// pageable applies the $skip offset, the $top limit and the $orderby orders to a query.
+type pageable struct {
+	skip   int
+	top    int
+	orders []sort.Order
+}
+
+// PageNumber returns the page, starting from 1, the $skip offset falls in for pages of $top records.
+func (p *pageable) PageNumber() int {
+	if p.top == 0 {
+		return 1
+	}
+	return p.skip/p.top + 1
+}
+
+// PageSize returns the $top limit.
+func (p *pageable) PageSize() int {
+	return p.top
+}
+
+// SortOrders returns the orders of $orderby.
+func (p *pageable) SortOrders() []sort.Order {
+	return p.orders
+}
+
+// Paginate applies the $skip offset, the $top limit and the $orderby orders to the GORM DB query.
+func (p *pageable) Paginate(db *gorm.DB) (*gorm.DB, error) {
+	db, err := p.Order(db)
+	return db.Offset(p.skip).Limit(p.top), err
+}
+
+// Order applies the $orderby orders to the GORM DB query.
+func (p *pageable) Order(db *gorm.DB) (*gorm.DB, error) {
+	if len(p.orders) > 0 {
+		db = db.Order(sort.OrderBy(p.orders))
+	}
+	return db, nil
+}
+
+// Filter returns the GORM DB query as is: $filter conditions are applied by Query.Where.
+func (p *pageable) Filter(db *gorm.DB) (*gorm.DB, error) {
+	return db, nil
+}
@@ -0,0 +1,152 @@
+package odata
+
+import (
+	"errors"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/javiorfo/gormen/filter"
+	"github.com/javiorfo/gormen/internal/testutils"
+)
+
+var registry = filter.Registry{
+	"id":           {Column: "id", Kind: filter.Int},
+	"username":     {Column: "username", Kind: filter.String},
+	"person/email": {Column: "Person.email", Kind: filter.String, Join: "Person"},
+}
+
+func TestParse(t *testing.T) {
+	values := url.Values{
+		"$filter":  {"id ge 2 and (startswith(username,'b_''x') or person/email eq null) and not id in (5, 6)"},
+		"$orderby": {"username desc,id"},
+		"$top":     {"5"},
+		"$skip":    {"10"},
+		"$select":  {"id, username"},
+		"$count":   {"true"},
+		"other":    {"ignored"},
+	}
+
+	query, err := Parse(values, registry, 100)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !query.Count || len(query.Select) != 2 || query.Select[0] != "id" || query.Select[1] != "username" {
+		t.Errorf("unexpected count %v or select %v", query.Count, query.Select)
+	}
+
+	if query.Pageable.PageNumber() != 3 || query.Pageable.PageSize() != 5 {
+		t.Errorf("expected page 3 of size 5, got page %d of size %d", query.Pageable.PageNumber(), query.Pageable.PageSize())
+	}
+
+	db := testutils.SetupDryRunDB("sqlite")
+	paginated, err := query.Pageable.Paginate(query.Where.Apply(db.Model(&testutils.UserDB{})).Select(query.Select))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := "SELECT `id`,`username`,`Person`.`id` AS `Person__id`,`Person`.`name` AS `Person__name`,`Person`.`email` AS `Person__email` FROM `users` LEFT JOIN `persons` `Person` ON `users`.`person_id` = `Person`.`id` " +
+		"WHERE (`users`.`id` >= 2 AND (`users`.`username` like \"b!_'x%\" escape '!' OR `Person`.`email` is null) AND NOT (`users`.`id` in (5,6))) " +
+		"ORDER BY username desc, id asc LIMIT 5 OFFSET 10"
+	if sql := paginated.Find(&[]testutils.UserDB{}).Statement; db.Dialector.Explain(sql.SQL.String(), sql.Vars...) != expected {
+		t.Errorf("expected %q, got %q", expected, db.Dialector.Explain(sql.SQL.String(), sql.Vars...))
+	}
+}
+
+func TestParse_OrderByAndSelectJoins(t *testing.T) {
+	values := url.Values{"$orderby": {"person/email desc"}, "$select": {"id,person/email"}}
+
+	query, err := Parse(values, registry, 10)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if joins := query.Where.Joins(); len(joins) != 1 || joins[0] != "Person" {
+		t.Fatalf("expected the Person join once, got %v", joins)
+	}
+
+	db, err := query.Pageable.Paginate(query.Where.Apply(testutils.SetupTestDB().Model(&testutils.UserDB{})))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := db.Find(&[]testutils.UserDB{}).Error; err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestParse_Defaults(t *testing.T) {
+	query, err := Parse(url.Values{}, registry, 50)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if query.Pageable.PageNumber() != 1 || query.Pageable.PageSize() != 50 || len(query.Where.Joins()) != 0 {
+		t.Errorf("unexpected defaults %+v", query)
+	}
+}
+
+func TestParse_InvalidMaxTop(t *testing.T) {
+	for _, maxTop := range []int{0, -1} {
+		if _, err := Parse(url.Values{}, registry, maxTop); err == nil {
+			t.Errorf("expected error for maxTop %d", maxTop)
+		}
+	}
+}
+
+func TestParse_ErrorOrder(t *testing.T) {
+	values := url.Values{"$top": {"x"}, "$expand": {"Person"}, "$skip": {"-1"}, "$count": {"yes"}}
+
+	for range 20 {
+		_, err := Parse(values, registry, 100)
+		if err == nil || !strings.HasPrefix(err.Error(), "$count:") {
+			t.Fatalf("expected the $count error first, got %v", err)
+		}
+	}
+}
+
+func TestParse_Errors(t *testing.T) {
+	tests := []struct {
+		name     string
+		values   url.Values
+		position int
+	}{
+		{"Unsupported option", url.Values{"$expand": {"Person"}}, -1},
+		{"Top over max", url.Values{"$top": {"101"}}, -1},
+		{"Negative skip", url.Values{"$skip": {"-1"}}, -1},
+		{"Invalid count", url.Values{"$count": {"yes"}}, -1},
+		{"Unsupported function", url.Values{"$filter": {"tolower(username) eq 'a'"}}, 0},
+		{"Unsupported operator", url.Values{"$filter": {"id has 1"}}, 3},
+		{"Unknown field", url.Values{"$filter": {"id eq 1 or password eq 'x'"}}, 11},
+		{"Unquoted string", url.Values{"$filter": {"username eq abc"}}, 12},
+		{"Invalid number", url.Values{"$filter": {"id in (1, x)"}}, 7},
+		{"Unclosed group", url.Values{"$filter": {"(id eq 1"}}, 8},
+		{"Unterminated string", url.Values{"$filter": {"username eq 'abc"}}, 12},
+		{"Unknown select", url.Values{"$select": {"id,password"}}, 3},
+		{"Invalid orderby", url.Values{"$orderby": {"id up"}}, 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse(tt.values, registry, 100)
+			if err == nil {
+				t.Fatal("expected error")
+			}
+
+			var syntaxErr *filter.SyntaxError
+			if tt.position < 0 {
+				if errors.As(err, &syntaxErr) {
+					t.Errorf("expected an option error, got %v", err)
+				}
+				return
+			}
+
+			if !errors.As(err, &syntaxErr) {
+				t.Fatalf("expected a syntax error, got %v", err)
+			}
+			if syntaxErr.Position != tt.position {
+				t.Errorf("expected position %d, got %d (%v)", tt.position, syntaxErr.Position, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,119 @@
+package filter
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"time"
+
+	"github.com/javiorfo/gormen/where"
+)
+
+// Kind is the type of the values a field is compared with.
+type Kind int
+
+const (
+	String Kind = iota
+	Int
+	Float
+	Bool
+	Time
+)
+
+// String returns the name of the kind.
+func (k Kind) String() string {
+	switch k {
+	case Int:
+		return "int"
+	case Float:
+		return "float"
+	case Bool:
+		return "bool"
+	case Time:
+		return "time"
+	default:
+		return "string"
+	}
+}
+
+// Field maps a public field name of a query language to a column and the kind of its values.
+type Field struct {
+	// Column name, qualified with the table, alias or relationship name of the join if any
+	Column where.ColumnName
+	// Kind of the values the column is compared with
+	Kind Kind
+	// Optional join clause or association name required by the column, e.g. "Person"
+	Join string
+}
+
+// Registry is the whitelist of fields a query can reference, by public name.
+// Names not in the registry are rejected, so clients can never reach other columns.
+type Registry map[string]Field
+
+// Lookup returns the field registered under the public name, or an error if there is none.
+func (r Registry) Lookup(name string) (Field, error) {
+	field, ok := r[name]
+	if !ok {
+		return Field{}, fmt.Errorf("unknown field '%s'", name)
+	}
+	return field, nil
+}
+
+// Value converts a value of a query to the kind of the field.
+// Strings are parsed, so query strings can be converted, while other values must already be of the kind:
+// any integer or float for Int and Float, a bool for Bool and a time.Time for Time.
+// Times are parsed as RFC 3339 or as a date (2006-01-02) in UTC.
+func (f Field) Value(value any) (any, error) {
+	if s, ok := value.(string); ok {
+		return f.parse(s)
+	}
+
+	v := reflect.ValueOf(value)
+	if !v.IsValid() {
+		return nil, fmt.Errorf("null is not a valid %s", f.Kind)
+	}
+
+	switch {
+	case f.Kind == Int && v.CanInt():
+		return v.Int(), nil
+	case f.Kind == Int && v.CanUint():
+		return int64(v.Uint()), nil
+	case f.Kind == Int && v.CanFloat() && v.Float() == float64(int64(v.Float())):
+		return int64(v.Float()), nil
+	case f.Kind == Float && (v.CanInt() || v.CanUint() || v.CanFloat()):
+		return v.Convert(reflect.TypeFor[float64]()).Interface(), nil
+	case f.Kind == Bool && v.Kind() == reflect.Bool:
+		return v.Bool(), nil
+	case f.Kind == Time && v.Type() == reflect.TypeFor[time.Time]():
+		return value, nil
+	}
+
+	return nil, fmt.Errorf("'%v' is not a valid %s", value, f.Kind)
+}
+
+// parse parses a string value to the kind of the field.
+func (f Field) parse(value string) (any, error) {
+	var parsed any
+	var err error
+
+	switch f.Kind {
+	case String:
+		return value, nil
+	case Int:
+		parsed, err = strconv.ParseInt(value, 10, 64)
+	case Float:
+		parsed, err = strconv.ParseFloat(value, 64)
+	case Bool:
+		parsed, err = strconv.ParseBool(value)
+	case Time:
+		parsed, err = time.Parse(time.RFC3339, value)
+		if err != nil {
+			parsed, err = time.Parse(time.DateOnly, value)
+		}
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("'%s' is not a valid %s", value, f.Kind)
+	}
+	return parsed, nil
+}
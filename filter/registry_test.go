@@ -0,0 +1,64 @@
+package filter
+
+import (
+	"testing"
+	"time"
+)
+
+func TestField_Value(t *testing.T) {
+	date := time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		kind     Kind
+		value    any
+		expected any
+		valid    bool
+	}{
+		{"String", String, "abc", "abc", true},
+		{"Int from string", Int, "42", int64(42), true},
+		{"Int from float without fraction", Int, 42.0, int64(42), true},
+		{"Int from float with fraction", Int, 4.2, nil, false},
+		{"Int from invalid string", Int, "4x", nil, false},
+		{"Float from int", Float, 3, float64(3), true},
+		{"Bool from string", Bool, "true", true, true},
+		{"Bool from int", Bool, 1, nil, false},
+		{"Time from date", Time, "2026-10-18", date, true},
+		{"Time from RFC 3339", Time, "2026-10-18T00:00:00Z", date, true},
+		{"Time from time", Time, date, date, true},
+		{"Time from invalid string", Time, "18/10/2026", nil, false},
+		{"Time from null", Time, nil, nil, false},
+		{"Int from null", Int, nil, nil, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			value, err := Field{Column: "column", Kind: tt.kind}.Value(tt.value)
+			if (err == nil) != tt.valid {
+				t.Fatalf("expected valid to be %v, got error %v", tt.valid, err)
+			}
+
+			if tt.valid && value != tt.expected {
+				t.Errorf("expected %v (%T), got %v (%T)", tt.expected, tt.expected, value, value)
+			}
+		})
+	}
+}
+
+func TestField_Condition(t *testing.T) {
+	if _, err := (Field{Column: "id", Kind: Int}).Condition(Like, "1*"); err == nil {
+		t.Error("expected error matching a pattern against an int field")
+	}
+
+	if _, err := (Field{Column: "id", Kind: Int}).Condition(Equal, 1, 2); err == nil {
+		t.Error("expected error comparing with many values")
+	}
+
+	if _, err := (Field{Column: "id", Kind: Int}).Condition(In, "1", 2); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	if _, err := (Registry{}).Lookup("id"); err == nil {
+		t.Error("expected error looking up an unknown field")
+	}
+}
@@ -0,0 +1,343 @@
+// Package rsql parses RSQL/FIQL query strings, such as
+// username==batch*;person.email=in=(a@x.com,b@x.com),enable==true, into a gormen.Where.
+package rsql
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"unicode"
+
+	"github.com/javiorfo/gormen"
+	"github.com/javiorfo/gormen/filter"
+	"github.com/javiorfo/gormen/where"
+)
+
+// reserved are the characters that end an unquoted field name or value.
+const reserved = `"'();,=!~<>`
+
+// operators maps the RSQL comparison operators, FIQL and their aliases, to filter operators.
+var operators = map[string]filter.Operator{
+	"==":        filter.Equal,
+	"!=":        filter.NotEqual,
+	"=lt=":      filter.LessThan,
+	"<":         filter.LessThan,
+	"=le=":      filter.LessOrEqual,
+	"<=":        filter.LessOrEqual,
+	"=gt=":      filter.GreaterThan,
+	">":         filter.GreaterThan,
+	"=ge=":      filter.GreaterOrEqual,
+	">=":        filter.GreaterOrEqual,
+	"=in=":      filter.In,
+	"=out=":     filter.NotIn,
+	"=isnull=":  filter.IsNull,
+	"=notnull=": filter.IsNull,
+}
+
+// Parse converts an RSQL expression into a gormen.Where, with the joins of the fields it references.
+// Fields are looked up by public name in the registry, and their values converted to the field kind.
+// The AND operator is ; or "and" and the OR operator , or "or", AND taking precedence; parentheses group.
+// Comparisons are ==, !=, =lt= (<), =le= (<=), =gt= (>), =ge= (>=), =in=, =out=, =isnull= and =notnull=,
+// the last two taking true or false. An unquoted * in the value of == or != matches any sequence of characters.
+// Values with reserved characters or spaces can be quoted with ' or ", escaping with a backslash.
+// Any problem is reported as a *filter.SyntaxError with its position in the input.
+// A blank input returns an empty Where.
+func Parse(input string, registry filter.Registry) (gormen.Where, error) {
+	p := &parser{input: input, registry: registry}
+
+	p.skipSpaces()
+	if p.eof() {
+		return gormen.Where{}, nil
+	}
+
+	condition, err := p.or()
+	if err != nil {
+		return gormen.Where{}, err
+	}
+
+	if p.skipSpaces(); !p.eof() {
+		return gormen.Where{}, p.errorf("unexpected '%c'", p.input[p.pos])
+	}
+
+	return gormen.NewWhere(condition).WithJoin(p.joins...).Build(), nil
+}
+
+// parser is a recursive descent parser over the input, collecting the joins of the fields found.
+type parser struct {
+	input    string
+	pos      int
+	registry filter.Registry
+	joins    []gormen.Join
+}
+
+// or parses constraints separated by the OR operator.
+func (p *parser) or() (where.Condition, error) {
+	var conditions []where.Condition
+	for {
+		c, err := p.and()
+		if err != nil {
+			return nil, err
+		}
+		conditions = append(conditions, c)
+
+		if !p.operator(',', "or") {
+			break
+		}
+	}
+
+	if len(conditions) == 1 {
+		return conditions[0], nil
+	}
+	return where.AnyOf(conditions...), nil
+}
+
+// and parses constraints separated by the AND operator.
+func (p *parser) and() (where.Condition, error) {
+	var conditions []where.Condition
+	for {
+		c, err := p.constraint()
+		if err != nil {
+			return nil, err
+		}
+		conditions = append(conditions, c)
+
+		if !p.operator(';', "and") {
+			break
+		}
+	}
+
+	if len(conditions) == 1 {
+		return conditions[0], nil
+	}
+	return where.AllOf(conditions...), nil
+}
+
+// operator consumes the logical operator, by symbol or keyword, if it is next in the input.
+// Keywords must be surrounded by spaces.
+func (p *parser) operator(symbol byte, keyword string) bool {
+	start := p.pos
+	p.skipSpaces()
+
+	if !p.eof() && p.input[p.pos] == symbol {
+		p.pos++
+		return true
+	}
+
+	end := p.pos + len(keyword)
+	if p.pos > start && end < len(p.input) && strings.EqualFold(p.input[p.pos:end], keyword) && unicode.IsSpace(rune(p.input[end])) {
+		p.pos = end
+		return true
+	}
+
+	p.pos = start
+	return false
+}
+
+// constraint parses a parenthesized expression or a comparison.
+func (p *parser) constraint() (where.Condition, error) {
+	p.skipSpaces()
+
+	if !p.eof() && p.input[p.pos] == '(' {
+		p.pos++
+		c, err := p.or()
+		if err != nil {
+			return nil, err
+		}
+
+		if p.skipSpaces(); p.eof() || p.input[p.pos] != ')' {
+			return nil, p.errorf("expected ')'")
+		}
+		p.pos++
+		return c, nil
+	}
+
+	return p.comparison()
+}
+
+// comparison parses a field name, a comparison operator and its arguments.
+func (p *parser) comparison() (where.Condition, error) {
+	start := p.pos
+	name := p.unreserved()
+	if name == "" {
+		return nil, p.errorf("expected field name")
+	}
+
+	field, err := p.registry.Lookup(name)
+	if err != nil {
+		return nil, &filter.SyntaxError{Position: start, Message: err.Error()}
+	}
+
+	operator, symbol, ok := p.comparisonOperator()
+	if !ok {
+		return nil, p.errorf("expected comparison operator")
+	}
+
+	argumentsStart := p.pos
+	values, quoted, err := p.arguments()
+	if err != nil {
+		return nil, err
+	}
+
+	if (operator == filter.In || operator == filter.NotIn) != (p.input[argumentsStart] == '(') {
+		return nil, &filter.SyntaxError{Position: argumentsStart, Message: "operator '" + symbol + "' does not take these arguments"}
+	}
+
+	if symbol == "=notnull=" {
+		values[0] = negate(values[0])
+	}
+
+	if len(values) == 1 && !quoted[0] && strings.Contains(values[0].(string), "*") {
+		switch operator {
+		case filter.Equal:
+			operator = filter.Like
+		case filter.NotEqual:
+			operator = filter.NotLike
+		}
+	}
+
+	condition, err := field.Condition(operator, values...)
+	if err != nil {
+		return nil, &filter.SyntaxError{Position: argumentsStart, Message: err.Error()}
+	}
+
+	if field.Join != "" && !slices.Contains(p.joins, field.Join) {
+		p.joins = append(p.joins, field.Join)
+	}
+
+	return condition, nil
+}
+
+// negate inverts a true or false value, keeping any other value for the conversion to report it.
+func negate(value any) any {
+	switch strings.ToLower(value.(string)) {
+	case "true":
+		return "false"
+	case "false":
+		return "true"
+	}
+	return value
+}
+
+// comparisonOperator parses a comparison operator, returning it with the symbol found.
+func (p *parser) comparisonOperator() (filter.Operator, string, bool) {
+	rest := p.input[p.pos:]
+
+	if strings.HasPrefix(rest, "=") && !strings.HasPrefix(rest, "==") {
+		if end := strings.IndexByte(rest[1:], '='); end > 0 {
+			symbol := rest[:end+2]
+			if operator, ok := operators[strings.ToLower(symbol)]; ok {
+				p.pos += len(symbol)
+				return operator, strings.ToLower(symbol), true
+			}
+		}
+		return 0, "", false
+	}
+
+	for _, symbol := range []string{"==", "!=", "<=", ">=", "<", ">"} {
+		if strings.HasPrefix(rest, symbol) {
+			p.pos += len(symbol)
+			return operators[symbol], symbol, true
+		}
+	}
+
+	return 0, "", false
+}
+
+// arguments parses a single value or a parenthesized list of values separated by commas,
+// reporting whether each one was quoted.
+func (p *parser) arguments() ([]any, []bool, error) {
+	if p.eof() || p.input[p.pos] != '(' {
+		value, quoted, err := p.value()
+		if err != nil {
+			return nil, nil, err
+		}
+		return []any{value}, []bool{quoted}, nil
+	}
+
+	p.pos++
+	var values []any
+	var quotes []bool
+	for {
+		p.skipSpaces()
+		value, quoted, err := p.value()
+		if err != nil {
+			return nil, nil, err
+		}
+		values = append(values, value)
+		quotes = append(quotes, quoted)
+
+		p.skipSpaces()
+		if p.eof() {
+			return nil, nil, p.errorf("expected ')'")
+		}
+
+		switch p.input[p.pos] {
+		case ',':
+			p.pos++
+		case ')':
+			p.pos++
+			return values, quotes, nil
+		default:
+			return nil, nil, p.errorf("expected ',' or ')'")
+		}
+	}
+}
+
+// value parses a quoted or unquoted value.
+func (p *parser) value() (string, bool, error) {
+	if p.eof() || (p.input[p.pos] != '"' && p.input[p.pos] != '\'') {
+		value := p.unreserved()
+		if value == "" {
+			return "", false, p.errorf("expected value")
+		}
+		return value, false, nil
+	}
+
+	start := p.pos
+	quote := p.input[p.pos]
+	p.pos++
+
+	var value strings.Builder
+	for !p.eof() {
+		c := p.input[p.pos]
+		switch {
+		case c == '\\' && p.pos+1 < len(p.input):
+			value.WriteByte(p.input[p.pos+1])
+			p.pos += 2
+		case c == quote:
+			p.pos++
+			return value.String(), true, nil
+		default:
+			value.WriteByte(c)
+			p.pos++
+		}
+	}
+
+	return "", false, &filter.SyntaxError{Position: start, Message: "unterminated quoted value"}
+}
+
+// unreserved consumes and returns the characters up to the next reserved character or space.
+func (p *parser) unreserved() string {
+	start := p.pos
+	for !p.eof() && !strings.ContainsRune(reserved, rune(p.input[p.pos])) && !unicode.IsSpace(rune(p.input[p.pos])) {
+		p.pos++
+	}
+	return p.input[start:p.pos]
+}
+
+// skipSpaces consumes any space at the current position.
+func (p *parser) skipSpaces() {
+	for !p.eof() && unicode.IsSpace(rune(p.input[p.pos])) {
+		p.pos++
+	}
+}
+
+// eof reports whether the whole input has been consumed.
+func (p *parser) eof() bool {
+	return p.pos >= len(p.input)
+}
+
+// errorf returns a SyntaxError at the current position.
+func (p *parser) errorf(format string, args ...any) error {
+	return &filter.SyntaxError{Position: p.pos, Message: fmt.Sprintf(format, args...)}
+}
@@ -0,0 +1,91 @@
+package rsql
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/javiorfo/gormen/filter"
+	"github.com/javiorfo/gormen/internal/testutils"
+)
+
+var registry = filter.Registry{
+	"id":           {Column: "id", Kind: filter.Int},
+	"username":     {Column: "username", Kind: filter.String},
+	"person.email": {Column: "Person.email", Kind: filter.String, Join: "Person"},
+}
+
+func TestParse(t *testing.T) {
+	db := testutils.SetupDryRunDB("sqlite")
+
+	tests := []struct {
+		name  string
+		input string
+		sql   string
+	}{
+		{"Blank", "  ", "SELECT * FROM `users`"},
+		{"Precedence", "username==batch*;person.email=in=(a@x.com,'b@x.com'),id=gt=1",
+			"SELECT `users`.`id`,`users`.`username`,`users`.`password`,`users`.`person_id`,`Person`.`id` AS `Person__id`,`Person`.`name` AS `Person__name`,`Person`.`email` AS `Person__email` " +
+				"FROM `users` LEFT JOIN `persons` `Person` ON `users`.`person_id` = `Person`.`id` " +
+				"WHERE ((`users`.`username` like \"batch%\" escape '!' AND `Person`.`email` in (\"a@x.com\",\"b@x.com\")) OR `users`.`id` > 1)"},
+		{"Keywords and groups", "id<=2 and (username!=jdoe or username=isnull=true)",
+			"SELECT * FROM `users` WHERE (`users`.`id` <= 2 AND (`users`.`username` <> \"jdoe\" OR `users`.`username` is null))"},
+		{"Quoted values keep reserved characters and stars", `username=="a*b; c" ; username!="x\"y"`,
+			"SELECT * FROM `users` WHERE (`users`.`username` = \"a*b; c\" AND `users`.`username` <> \"x\"\"y\")"},
+		{"FIQL operators", "id=out=(1,2);username=notnull=true;username!=*test",
+			"SELECT * FROM `users` WHERE (`users`.`id` not in (1,2) AND `users`.`username` is not null AND NOT (`users`.`username` like \"%test\" escape '!'))"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w, err := Parse(tt.input, registry)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			statement, err := w.ToSQL(db, &testutils.UserDB{})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if sql := statement.String(); sql != tt.sql {
+				t.Errorf("expected %q, got %q", tt.sql, sql)
+			}
+		})
+	}
+}
+
+func TestParse_Errors(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		position int
+	}{
+		{"Unknown field", "username==a;password==b", 12},
+		{"Missing operator", "username", 8},
+		{"Unknown operator", "id=like=1", 2},
+		{"Invalid value", "username==a;id=gt=one", 18},
+		{"Missing value", "id==", 4},
+		{"List for single value operator", "id==(1,2)", 4},
+		{"Single value for list operator", "id=in=1", 6},
+		{"Unclosed group", "(id==1;id==2", 12},
+		{"Unclosed list", "id=in=(1,2", 10},
+		{"Unterminated quote", "username=='abc", 10},
+		{"Trailing input", "id==1)", 5},
+		{"Dangling operator", "id==1;", 6},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse(tt.input, registry)
+
+			var syntaxErr *filter.SyntaxError
+			if !errors.As(err, &syntaxErr) {
+				t.Fatalf("expected a syntax error, got %v", err)
+			}
+
+			if syntaxErr.Position != tt.position {
+				t.Errorf("expected position %d, got %d (%v)", tt.position, syntaxErr.Position, err)
+			}
+		})
+	}
+}
@@ -85,3 +85,24 @@ func SetupTestDB() *gorm.DB {
 
 	return db
 }
+
+// dialector renders SQL like the wrapped dialector while reporting another dialect name.
+type dialector struct {
+	gorm.Dialector
+	name string
+}
+
+func (d dialector) Name() string {
+	return d.name
+}
+
+// SetupDryRunDB returns a database that only renders SQL, reporting the given dialect name
+// so dialect specific conditions can be checked without that database.
+func SetupDryRunDB(name string) *gorm.DB {
+	db, err := gorm.Open(dialector{sqlite.Open(":memory:"), name}, &gorm.Config{DryRun: true})
+	if err != nil {
+		log.Fatalf("failed to connect database: %v", err)
+	}
+
+	return db
+}
@@ -1,9 +1,11 @@
 package utils
 
 import (
+	"reflect"
 	"strings"
 
 	"github.com/javiorfo/nilo"
+	"gorm.io/gorm"
 )
 
 // GetValueAsCommaSeparated attempts to convert a value to a slice of strings
@@ -16,3 +18,48 @@ func GetValueAsCommaSeparated(value any) nilo.Option[[]string] {
 	}
 	return nilo.Value(strings.Split(str, ","))
 }
+
+// nilable is satisfied by nilo.Option values of any type.
+type nilable interface {
+	IsNil() bool
+	IsValue() bool
+}
+
+// GetValueAsNullable resolves a value that may represent SQL NULL.
+// Returns Nil if the value is nil, a nil pointer or an empty nilo.Option;
+// otherwise returns an Option containing the value, unwrapping nilo.Option values.
+func GetValueAsNullable(value any) nilo.Option[any] {
+	if value == nil {
+		return nilo.Nil[any]()
+	}
+
+	if opt, ok := value.(nilable); ok {
+		if opt.IsNil() {
+			return nilo.Nil[any]()
+		}
+		return nilo.Value(reflect.ValueOf(value).MethodByName("AsValue").Call(nil)[0].Interface())
+	}
+
+	if v := reflect.ValueOf(value); v.Kind() == reflect.Pointer && v.IsNil() {
+		return nilo.Nil[any]()
+	}
+
+	return nilo.Value(value)
+}
+
+// HasJoin reports whether the GORM DB query already has the join, an association name or a raw join clause.
+// Raw clauses are compared ignoring case and differences in spacing, so the same join declared in several
+// places is only added once.
+func HasJoin(db *gorm.DB, join string) bool {
+	for _, j := range db.Statement.Joins {
+		if normalizeJoin(j.Name) == normalizeJoin(join) {
+			return true
+		}
+	}
+	return false
+}
+
+// normalizeJoin lowercases the join and collapses its spaces.
+func normalizeJoin(join string) string {
+	return strings.ToLower(strings.Join(strings.Fields(join), " "))
+}
@@ -3,6 +3,7 @@ package utils
 import (
 	"testing"
 
+	"github.com/javiorfo/gormen/internal/testutils"
 	"github.com/javiorfo/nilo"
 )
 
@@ -99,3 +100,55 @@ func TestGetValueAsCommaSeparated(t *testing.T) {
 		})
 	}
 }
+
+func TestGetValueAsNullable(t *testing.T) {
+	var nilPtr *string
+	str := "value"
+
+	tests := []struct {
+		name     string
+		input    any
+		isNil    bool
+		expected any
+	}{
+		{name: "Nil input", input: nil, isNil: true},
+		{name: "Nil pointer", input: nilPtr, isNil: true},
+		{name: "Empty option", input: nilo.Nil[string](), isNil: true},
+		{name: "Option with value", input: nilo.Value("value"), expected: "value"},
+		{name: "Pointer with value", input: &str, expected: &str},
+		{name: "Plain value", input: 10, expected: 10},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			actual := GetValueAsNullable(tt.input)
+			if actual.IsNil() != tt.isNil {
+				t.Fatalf("Expected IsNil() to be %v, got %v", tt.isNil, actual.IsNil())
+			}
+
+			if !tt.isNil && actual.AsValue() != tt.expected {
+				t.Errorf("Expected %v, got %v", tt.expected, actual.AsValue())
+			}
+		})
+	}
+}
+
+func TestHasJoin(t *testing.T) {
+	db := testutils.SetupDryRunDB("sqlite").Joins("Person").Joins("inner join persons p on p.id = users.person_id")
+
+	tests := []struct {
+		join     string
+		expected bool
+	}{
+		{"Person", true},
+		{"INNER JOIN persons p  on p.id = users.person_id ", true},
+		{"left join persons p on p.id = users.person_id", false},
+		{"Roles", false},
+	}
+
+	for _, tt := range tests {
+		if got := HasJoin(db, tt.join); got != tt.expected {
+			t.Errorf("HasJoin(%q) expected %v, got %v", tt.join, tt.expected, got)
+		}
+	}
+}
@@ -0,0 +1,184 @@
+package pagination
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ParamErrors holds the errors of the query parameters that could not be bound to a filter struct,
+// by parameter name.
+type ParamErrors map[string]error
+
+// Error returns the errors of all the parameters, sorted by name.
+func (e ParamErrors) Error() string {
+	params := make([]string, 0, len(e))
+	for param := range e {
+		params = append(params, param)
+	}
+	slices.Sort(params)
+
+	messages := make([]string, len(params))
+	for i, param := range params {
+		messages[i] = fmt.Sprintf("'%s' %s", param, e[param])
+	}
+	return strings.Join(messages, "; ")
+}
+
+// BindFilter returns a filter struct of type T with the fields tagged with "param" set from the
+// query parameters of that name, e.g. `param:"person.email" filter:"people.email = ?"`.
+// Strings, numbers, bools, time.Time (RFC3339 or 2006-01-02), pointers to them and slices are converted;
+// a slice takes the repeated parameters or a single comma-separated one, and a Range the parameters
+// with the .from and .to suffixes, e.g. ?created.from=2025-01-01&created.to=2025-02-01.
+// Missing parameters and fields without the tag keep their zero value; untagged struct fields,
+// such as embedded filter structs, are bound by their own fields.
+// Values that cannot be converted, and parameters tagged on unexported fields, are reported together as ParamErrors.
+func BindFilter[T any](values url.Values) (T, error) {
+	var filter T
+
+	v := reflect.ValueOf(&filter).Elem()
+	if v.Kind() != reflect.Struct {
+		return filter, errors.New("'filter' must be a struct")
+	}
+
+	paramErrors := ParamErrors{}
+	bindStruct(v, values, paramErrors)
+
+	if len(paramErrors) > 0 {
+		return filter, paramErrors
+	}
+	return filter, nil
+}
+
+// bindStruct sets the tagged fields of the struct from the values, descending into the untagged
+// struct fields, such as embedded filter structs, and reporting conversion errors by parameter name.
+func bindStruct(v reflect.Value, values url.Values, paramErrors ParamErrors) {
+	for i := range v.NumField() {
+		field := v.Field(i)
+		structField := v.Type().Field(i)
+		param := structField.Tag.Get("param")
+
+		if param == "" {
+			if field.Kind() == reflect.Struct && field.Type() != timeType && (structField.IsExported() || structField.Anonymous) {
+				bindStruct(field, values, paramErrors)
+			}
+			continue
+		}
+
+		if !structField.IsExported() {
+			paramErrors[param] = fmt.Errorf("cannot be bound to the unexported field %s", structField.Name)
+			continue
+		}
+
+		if field.Kind() == reflect.Pointer {
+			if binder, ok := reflect.New(field.Type().Elem()).Interface().(rangeBinder); ok {
+				if binder.bind(param, values, paramErrors); binder.(rangeFilter).IsSet() {
+					field.Set(reflect.ValueOf(binder))
+				}
+				continue
+			}
+		}
+		if binder, ok := field.Addr().Interface().(rangeBinder); ok {
+			binder.bind(param, values, paramErrors)
+			continue
+		}
+
+		params, ok := values[param]
+		if !ok || len(params) == 0 {
+			continue
+		}
+
+		if err := bindValue(field, params); err != nil {
+			paramErrors[param] = err
+		}
+	}
+}
+
+// WithFilterFromQuery adds a filter struct of type T bound from the query parameters to the pageRequest.
+// See BindFilter.
+func WithFilterFromQuery[T any](values url.Values) PageOptions {
+	return func(p *pageRequest) error {
+		filter, err := BindFilter[T](values)
+		if err != nil {
+			return err
+		}
+		return WithFilter(filter)(p)
+	}
+}
+
+// timeType is the type of time.Time, bound from a string instead of as a struct.
+var timeType = reflect.TypeFor[time.Time]()
+
+// bindValue sets the field from the values of its parameter.
+func bindValue(field reflect.Value, values []string) error {
+	switch {
+	case field.Kind() == reflect.Pointer:
+		value := reflect.New(field.Type().Elem())
+		if err := bindValue(value.Elem(), values); err != nil {
+			return err
+		}
+		field.Set(value)
+		return nil
+	case field.Kind() == reflect.Slice:
+		if len(values) == 1 {
+			values = strings.Split(values[0], ",")
+		}
+		slice := reflect.MakeSlice(field.Type(), len(values), len(values))
+		for i, value := range values {
+			if err := bindValue(slice.Index(i), []string{strings.TrimSpace(value)}); err != nil {
+				return err
+			}
+		}
+		field.Set(slice)
+		return nil
+	}
+
+	value := values[0]
+	switch field.Kind() {
+	case reflect.String:
+		field.SetString(value)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return fmt.Errorf("must be true or false, got '%s'", value)
+		}
+		field.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(value, 10, field.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("must be an integer, got '%s'", value)
+		}
+		field.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(value, 10, field.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("must be a non-negative integer, got '%s'", value)
+		}
+		field.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(value, field.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("must be a number, got '%s'", value)
+		}
+		field.SetFloat(f)
+	case reflect.Struct:
+		if field.Type() != timeType {
+			return fmt.Errorf("cannot be bound to a %s", field.Type())
+		}
+		t, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			if t, err = time.Parse(time.DateOnly, value); err != nil {
+				return fmt.Errorf("must be a date or an RFC3339 time, got '%s'", value)
+			}
+		}
+		field.Set(reflect.ValueOf(t))
+	default:
+		return fmt.Errorf("cannot be bound to a %s", field.Type())
+	}
+	return nil
+}
@@ -0,0 +1,188 @@
+package pagination
+
+import (
+	"errors"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/javiorfo/gormen/internal/testutils"
+	"github.com/javiorfo/nilo"
+)
+
+type boundFilter struct {
+	Username string    `param:"username" filter:"username = ?"`
+	Email    *string   `param:"person.email" filter:"Person.email = ?;join:Person"`
+	IDs      []int     `param:"id" filter:"users.id in ?"`
+	Enable   bool      `param:"enable" filter:"enable = ?"`
+	Since    time.Time `param:"since" filter:"created_at >= ?"`
+	Score    *float64  `param:"score" filter:"score > ?"`
+	Ignored  string    `filter:"ignored = ?"`
+}
+
+func TestBindFilter(t *testing.T) {
+	values := url.Values{
+		"username":     {"batch"},
+		"person.email": {"b@mail.com"},
+		"id":           {"1", "2"},
+		"enable":       {"true"},
+		"since":        {"2025-03-01"},
+		"ignored":      {"x"},
+	}
+
+	filter, err := BindFilter[boundFilter](values)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if filter.Username != "batch" || filter.Email == nil || *filter.Email != "b@mail.com" || !filter.Enable {
+		t.Errorf("unexpected filter %+v", filter)
+	}
+	if len(filter.IDs) != 2 || filter.IDs[0] != 1 || filter.IDs[1] != 2 {
+		t.Errorf("expected ids [1 2], got %v", filter.IDs)
+	}
+	if !filter.Since.Equal(time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("unexpected since %v", filter.Since)
+	}
+	if filter.Score != nil || filter.Ignored != "" {
+		t.Errorf("expected unbound fields to keep their zero value, got %+v", filter)
+	}
+
+	filter, err = BindFilter[boundFilter](url.Values{"id": {"3, 4,5"}, "since": {"2025-03-01T10:00:00-03:00"}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(filter.IDs) != 3 || filter.IDs[2] != 5 || filter.Since.UTC().Hour() != 13 {
+		t.Errorf("unexpected filter %+v", filter)
+	}
+}
+
+func TestBindFilter_Errors(t *testing.T) {
+	values := url.Values{
+		"username": {"batch"},
+		"id":       {"1", "two"},
+		"enable":   {"maybe"},
+		"since":    {"yesterday"},
+		"score":    {"high"},
+	}
+
+	_, err := BindFilter[boundFilter](values)
+
+	var paramErrors ParamErrors
+	if !errors.As(err, &paramErrors) {
+		t.Fatalf("expected ParamErrors, got %v", err)
+	}
+
+	for _, param := range []string{"id", "enable", "since", "score"} {
+		if paramErrors[param] == nil {
+			t.Errorf("expected an error for '%s'", param)
+		}
+	}
+	if len(paramErrors) != 4 {
+		t.Errorf("expected 4 errors, got %v", paramErrors)
+	}
+
+	expected := "'enable' must be true or false, got 'maybe'; 'id' must be an integer, got 'two'; " +
+		"'score' must be a number, got 'high'; 'since' must be a date or an RFC3339 time, got 'yesterday'"
+	if err.Error() != expected {
+		t.Errorf("expected %q, got %q", expected, err.Error())
+	}
+
+	if _, err := BindFilter[string](values); err == nil {
+		t.Error("expected error for a non-struct filter")
+	}
+}
+
+func TestBindFilter_UnexportedField(t *testing.T) {
+	type unexportedFilter struct {
+		Username string `param:"username" filter:"column:username"`
+		secret   string `param:"secret" filter:"column:password"`
+	}
+
+	_, err := BindFilter[unexportedFilter](url.Values{"username": {"batch"}, "secret": {"x"}})
+
+	var paramErrors ParamErrors
+	if !errors.As(err, &paramErrors) || len(paramErrors) != 1 || paramErrors["secret"] == nil {
+		t.Fatalf("expected an error for 'secret', got %v", err)
+	}
+}
+
+func TestWithFilterFromQuery(t *testing.T) {
+	type userFilter struct {
+		Username string `param:"username" filter:"username = ?"`
+		IDs      []int  `param:"id" filter:"users.id in ?"`
+	}
+
+	p, err := PageRequestFrom(1, 10, WithFilterFromQuery[userFilter](url.Values{"username": {"batch"}, "id": {"1,2"}}))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	gotDB, err := p.Filter(testutils.SetupDryRunDB("sqlite"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	stmt := gotDB.Find(&[]testutils.UserDB{}).Statement
+	expected := "SELECT * FROM `users` WHERE username = ? AND users.id in (?,?)"
+	if sql := stmt.SQL.String(); sql != expected {
+		t.Errorf("expected %q, got %q", expected, sql)
+	}
+
+	if _, err := PageRequestFrom(1, 10, WithFilterFromQuery[userFilter](url.Values{"id": {"x"}})); err == nil {
+		t.Error("expected error for an invalid parameter")
+	}
+}
+
+func TestBindFilter_Nested(t *testing.T) {
+	type search struct {
+		Query string `param:"q" filter:"column:username;op:contains"`
+	}
+	type nested struct {
+		search
+		Search search `filter:"group:search"`
+		Page   int    `param:"minId" filter:"column:id;op:gte"`
+	}
+
+	filter, err := BindFilter[nested](url.Values{"q": {"bat"}, "minId": {"3"}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if filter.search.Query != "bat" || filter.Search.Query != "bat" || filter.Page != 3 {
+		t.Errorf("unexpected filter %+v", filter)
+	}
+}
+
+func TestBindFilter_AbsentSliceDoesNotFilter(t *testing.T) {
+	type sliceFilter struct {
+		IDs     []int     `param:"id" filter:"column:id;op:in"`
+		Emails  []string  `param:"person.email" filter:"users.username in ?"`
+		Between [2]string `filter:"column:username;op:between"`
+	}
+
+	filter, err := BindFilter[sliceFilter](url.Values{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	dryRun := testutils.SetupDryRunDB("sqlite")
+	gotDB, err := filterValues(dryRun, nilo.Value(any(filter)))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := "SELECT * FROM `users`"
+	if sql := gotDB.Find(&[]testutils.UserDB{}).Statement.SQL.String(); sql != expected {
+		t.Errorf("expected %q, got %q", expected, sql)
+	}
+
+	gotDB, err = filterValues(dryRun, nilo.Value(any(sliceFilter{IDs: []int{}, Between: [2]string{"a", "b"}})))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected = "SELECT * FROM `users` WHERE `users`.`username` between ? and ?"
+	if sql := gotDB.Find(&[]testutils.UserDB{}).Statement.SQL.String(); sql != expected {
+		t.Errorf("expected %q, got %q", expected, sql)
+	}
+}
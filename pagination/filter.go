@@ -7,8 +7,10 @@ import (
 	"strings"
 
 	"github.com/javiorfo/gormen/internal/utils"
+	"github.com/javiorfo/gormen/where"
 	"github.com/javiorfo/nilo"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 // tagAndValue holds a parsed filter tag, its corresponding field value,
@@ -16,10 +18,39 @@ import (
 type tagAndValue struct {
 	tagValue   string
 	fieldValue any
-	joins      string
+	joins      []string
+	jsonPath   clause.Expression
+	condition  where.Condition
+	group      string
+}
+
+// expression returns the filter as a Gorm clause expression, with the field value bound to its placeholder.
+func (v tagAndValue) expression() clause.Expression {
+	switch {
+	case v.condition != nil:
+		return v.condition
+	case v.jsonPath != nil:
+		return clause.Expr{SQL: v.tagValue, Vars: []any{v.jsonPath, v.fieldValue}}
+	default:
+		return clause.Expr{SQL: v.tagValue, Vars: []any{v.fieldValue}}
+	}
 }
 
 // filterValues applies filtering conditions from a struct with "filter" tags to a GORM DB query.
+// A tag is either raw SQL with a placeholder for the field value, e.g. `filter:"username = ?"`,
+// or declarative, naming the column and the operator, e.g. `filter:"column:username;op:like"`,
+// whose column is checked against the schema of the entity and its joins when the query runs.
+// A "json:column:path" tag part binds the value at the path of a JSON column to the first
+// placeholder of the filter, e.g. `filter:"? = ?;json:attrs:$.color"`, rendered for the dialect.
+// A "join:" tag part names an association of the entity, e.g. `join:Person`, joined with the ON clause
+// of its Gorm relationship, or is a raw join clause. Each join is added once, even when several fields
+// or the Where clause applied before declare it.
+// Embedded structs and struct fields without a "filter" tag are filtered by their own fields.
+// Fields tagged with the same "group:name" option, or within a struct field tagged with it,
+// are joined by OR inside parentheses; the rest of the conditions are joined by AND.
+// A Range field filters the column of its tag by its set bounds.
+// Comma-separated strings are split into a list for raw tags and the in and notin operators only.
+// Fields that are unset, such as nil pointers or empty nilo.Option values, do not filter; see setValue.
 // It takes an optional filter struct wrapped in nilo.Option and returns the modified DB instance.
 // Returns an error if any struct field lacks the "filter" tag.
 func filterValues(db *gorm.DB, filter nilo.Option[any]) (*gorm.DB, error) {
@@ -28,8 +59,47 @@ func filterValues(db *gorm.DB, filter nilo.Option[any]) (*gorm.DB, error) {
 	}
 
 	var results []tagAndValue
+	if err := collectValues(reflect.ValueOf(filter.AsValue()), "", &results); err != nil {
+		return db, err
+	}
+
+	var groups []string
+	grouped := make(map[string][]where.Condition)
+	for _, v := range results {
+		for _, join := range v.joins {
+			if !utils.HasJoin(db, join) {
+				db = db.Joins(join)
+			}
+		}
+		if v.group != "" {
+			if _, ok := grouped[v.group]; !ok {
+				groups = append(groups, v.group)
+			}
+			grouped[v.group] = append(grouped[v.group], v.expression())
+			continue
+		}
+		if v.condition != nil {
+			db = db.Where(v.condition)
+			continue
+		}
+		if v.jsonPath != nil {
+			db = db.Where(v.tagValue, v.jsonPath, v.fieldValue)
+			continue
+		}
+		db = db.Where(v.tagValue, v.fieldValue)
+	}
+
+	for _, group := range groups {
+		db = db.Where(where.AnyOf(grouped[group]...))
+	}
 
-	v := reflect.ValueOf(filter.AsValue())
+	return db, nil
+}
+
+// collectValues appends the filters of the set fields of the struct, in order, to the results,
+// descending into embedded and nested filter structs. Fields are put in the given group
+// unless their tag names one.
+func collectValues(v reflect.Value, group string, results *[]tagAndValue) error {
 	t := v.Type()
 
 	for i := range t.NumField() {
@@ -37,41 +107,197 @@ func filterValues(db *gorm.DB, filter nilo.Option[any]) (*gorm.DB, error) {
 		value := v.Field(i)
 
 		tagValue := field.Tag.Get("filter")
-		if tagValue == "" {
-			return db, errors.New("'filter' tag must exist in all properties")
+		parts := strings.Split(tagValue, ";")
+
+		fieldGroup := group
+		for _, part := range parts {
+			if after, ok := strings.CutPrefix(part, "group:"); ok {
+				fieldGroup = after
+			}
 		}
 
-		if fmt.Sprintf("%v", value.Interface()) == "" {
+		if nested, ok := nestedStruct(field, value, tagValue); ok {
+			if !nested.IsValid() {
+				continue
+			}
+			if err := collectValues(nested, fieldGroup, results); err != nil {
+				return err
+			}
 			continue
 		}
 
-		parts := strings.Split(tagValue, ";")
+		if tagValue == "" {
+			return errors.New("'filter' tag must exist in all properties")
+		}
+
 		filterString := parts[0]
 
-		var joins string
-		for _, part := range parts[1:] {
+		var joins []string
+		var columnName, operator string
+		var jsonPath clause.Expression
+		var omitZero bool
+		for _, part := range parts {
+			if part == "omitzero" {
+				omitZero = true
+			}
 			if after, ok := strings.CutPrefix(part, "join:"); ok {
-				joins = fmt.Sprintf("%s %s ", joins, after)
+				joins = append(joins, strings.TrimSpace(after))
+			}
+			if after, ok := strings.CutPrefix(part, "json:"); ok {
+				column, path, _ := strings.Cut(after, ":")
+				jsonPath = where.JSONPath(column, path)
+			}
+			if after, ok := strings.CutPrefix(part, "column:"); ok {
+				columnName = after
+			}
+			if after, ok := strings.CutPrefix(part, "op:"); ok {
+				operator = after
 			}
 		}
 
-		fieldValue := value.Interface()
-		utils.GetValueAsCommaSeparated(fieldValue).Consume(func(s []string) {
-			fieldValue = s
-		})
-		results = append(results, tagAndValue{
+		if r, ok := value.Interface().(rangeFilter); ok {
+			if columnName == "" {
+				return fmt.Errorf("'%s': a Range requires the 'column:' tag option", field.Name)
+			}
+			if value.Kind() == reflect.Pointer && value.IsNil() || !r.IsSet() {
+				continue
+			}
+			*results = append(*results, tagAndValue{joins: joins, condition: r.Condition(columnName), group: fieldGroup})
+			continue
+		}
+
+		fieldValue, ok := setValue(value, omitZero)
+		if !ok {
+			continue
+		}
+		var condition where.Condition
+		if columnName != "" {
+			var err error
+			if condition, err = operatorCondition(columnName, operator, fieldValue); err != nil {
+				return fmt.Errorf("'%s': %w", field.Name, err)
+			}
+		} else {
+			utils.GetValueAsCommaSeparated(fieldValue).Consume(func(s []string) {
+				fieldValue = s
+			})
+		}
+
+		*results = append(*results, tagAndValue{
 			tagValue:   filterString,
 			fieldValue: fieldValue,
 			joins:      joins,
+			jsonPath:   jsonPath,
+			condition:  condition,
+			group:      fieldGroup,
 		})
 	}
 
-	for _, v := range results {
-		if v.joins != "" {
-			db = db.Joins(v.joins)
-		}
-		db = db.Where(v.tagValue, v.fieldValue)
+	return nil
+}
+
+// nestedStruct reports whether the field is a filter struct to descend into: an embedded struct,
+// or a struct field whose tag, if any, only names a group. Pointers to structs are followed,
+// returning an invalid value when nil. time.Time, nilo.Option and Range fields are values, not filter structs.
+func nestedStruct(field reflect.StructField, value reflect.Value, tag string) (reflect.Value, bool) {
+	t := field.Type
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct || t == timeType {
+		return reflect.Value{}, false
+	}
+	if _, isOption := reflect.Zero(t).Interface().(interface{ IsValue() bool }); isOption {
+		return reflect.Value{}, false
+	}
+	if _, isRange := reflect.Zero(t).Interface().(rangeFilter); isRange {
+		return reflect.Value{}, false
+	}
+	if !field.Anonymous && tag != "" && !strings.HasPrefix(tag, "group:") {
+		return reflect.Value{}, false
 	}
 
-	return db, nil
+	if value.Kind() == reflect.Pointer {
+		return value.Elem(), true
+	}
+	return value, true
+}
+
+// setValue returns the value to filter a field by, reporting false if the field is unset and must not filter.
+// Nil pointers and empty nilo.Option fields are unset, while a pointer or nilo.Option holding a zero value
+// filters by it. Other fields are unset when they are an empty string, an empty slice, a zero array or,
+// with the "omitzero" tag option, their zero value.
+func setValue(value reflect.Value, omitZero bool) (any, bool) {
+	nullable := utils.GetValueAsNullable(value.Interface())
+	if nullable.IsNil() {
+		return nil, false
+	}
+
+	if value.Kind() == reflect.Pointer {
+		return value.Elem().Interface(), true
+	}
+	if _, isOption := value.Interface().(interface{ IsValue() bool }); isOption {
+		return nullable.AsValue(), true
+	}
+
+	switch {
+	case value.Kind() == reflect.String && value.String() == "",
+		value.Kind() == reflect.Slice && value.Len() == 0,
+		value.Kind() == reflect.Array && value.IsZero(),
+		omitZero && value.IsZero():
+		return nil, false
+	}
+	return value.Interface(), true
+}
+
+// operatorCondition returns the where condition of a declarative filter tag for the column and value.
+// The operator defaults to eq; between takes a slice or array of two values, and isnull a bool
+// matching null values if true and non null ones if false.
+func operatorCondition(column where.ColumnName, operator string, value any) (where.Condition, error) {
+	switch operator {
+	case "", "eq":
+		return where.Equal(column, value), nil
+	case "ne":
+		return where.NotEqual(column, value), nil
+	case "lt":
+		return where.LessThan(column, value), nil
+	case "lte":
+		return where.LessOrEqual(column, value), nil
+	case "gt":
+		return where.GreaterThan(column, value), nil
+	case "gte":
+		return where.GreaterOrEqual(column, value), nil
+	case "in":
+		return where.In(column, value), nil
+	case "notin":
+		return where.NotIn(column, value), nil
+	case "like":
+		return where.Like(column, value), nil
+	case "notlike":
+		return where.NotLike(column, value), nil
+	case "ilike":
+		return where.ILike(column, fmt.Sprint(value)), nil
+	case "contains":
+		return where.Contains(column, fmt.Sprint(value)), nil
+	case "startswith":
+		return where.StartsWith(column, fmt.Sprint(value)), nil
+	case "endswith":
+		return where.EndsWith(column, fmt.Sprint(value)), nil
+	case "between":
+		v := reflect.ValueOf(value)
+		if (v.Kind() != reflect.Slice && v.Kind() != reflect.Array) || v.Len() != 2 {
+			return nil, errors.New("'op:between' requires two values")
+		}
+		return where.Between(column, v.Index(0).Interface(), v.Index(1).Interface()), nil
+	case "isnull":
+		null, ok := value.(bool)
+		if !ok {
+			return nil, errors.New("'op:isnull' requires a bool")
+		}
+		if null {
+			return where.IsNull(column), nil
+		}
+		return where.IsNotNull(column), nil
+	default:
+		return nil, fmt.Errorf("'op:%s' is not a valid filter operator", operator)
+	}
 }
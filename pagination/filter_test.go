@@ -1,9 +1,12 @@
 package pagination
 
 import (
+	"errors"
 	"testing"
+	"time"
 
 	"github.com/javiorfo/gormen/internal/testutils"
+	"github.com/javiorfo/gormen/where"
 	"github.com/javiorfo/nilo"
 	"gorm.io/gorm"
 )
@@ -56,3 +59,263 @@ func TestFilterValues_FilterWithJoins(t *testing.T) {
 		t.Fatalf("expected non-nil Gorm DB")
 	}
 }
+
+func TestFilterValues_JSONPath(t *testing.T) {
+	type jsonFilter struct {
+		Color string `filter:"? = ?;json:username:$.color"`
+	}
+
+	gotDB, err := filterValues(testutils.SetupDryRunDB("sqlite"), nilo.Value(any(jsonFilter{Color: "red"})))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	stmt := gotDB.Find(&[]testutils.UserDB{}).Statement
+	expected := "SELECT * FROM `users` WHERE json_extract(`users`.`username`, '$.color') = ?"
+	if sql := stmt.SQL.String(); sql != expected {
+		t.Errorf("expected %q, got %q", expected, sql)
+	}
+}
+
+func TestFilterValues_Declarative(t *testing.T) {
+	type declarativeFilter struct {
+		Username string   `filter:"column:username;op:like"`
+		Email    string   `filter:"column:persons.email;op:contains;join:inner join persons on persons.id = users.person_id"`
+		IDs      string   `filter:"column:id;op:in"`
+		Range    [2]int   `filter:"column:person_id;op:between"`
+		Password bool     `filter:"column:password;op:isnull"`
+		Name     string   `filter:"column:username"`
+		Raw      string   `filter:"users.id > ?"`
+		Excluded []string `filter:"column:username;op:notin"`
+	}
+
+	f := declarativeFilter{Username: "b%", Email: "mail_", IDs: "1,2", Range: [2]int{3, 9}, Name: "batch", Raw: "0", Excluded: []string{"x"}}
+	gotDB, err := filterValues(testutils.SetupDryRunDB("sqlite"), nilo.Value(any(f)))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	stmt := gotDB.Find(&[]testutils.UserDB{}).Statement
+	if gotDB.Error != nil {
+		t.Fatalf("unexpected error: %v", gotDB.Error)
+	}
+
+	expected := "SELECT `users`.`id`,`users`.`username`,`users`.`password`,`users`.`person_id` FROM `users` " +
+		"inner join persons on persons.id = users.person_id " +
+		"WHERE `users`.`username` like ? AND `persons`.`email` like ? escape '!' AND `users`.`id` in (?,?) " +
+		"AND `users`.`person_id` between ? and ? AND `users`.`password` is not null AND `users`.`username` = ? " +
+		"AND users.id > ? AND `users`.`username` not in (?)"
+	if sql := stmt.SQL.String(); sql != expected {
+		t.Errorf("expected %q, got %q", expected, sql)
+	}
+}
+
+func TestFilterValues_DeclarativeKeepsCommas(t *testing.T) {
+	type commaFilter struct {
+		Name     string `filter:"column:username;op:eq"`
+		Pattern  string `filter:"column:username;op:like"`
+		Password string `filter:"column:password;op:contains"`
+	}
+
+	f := commaFilter{Name: "Doe, John", Pattern: "Doe,%", Password: "a,b"}
+	gotDB, err := filterValues(db, nilo.Value(any(f)))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := gotDB.Find(&[]testutils.UserDB{}).Error; err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	gotDB, _ = filterValues(testutils.SetupDryRunDB("sqlite"), nilo.Value(any(f)))
+	stmt := gotDB.Find(&[]testutils.UserDB{}).Statement
+
+	expected := []any{"Doe, John", "Doe,%", "%a,b%"}
+	if len(stmt.Vars) != len(expected) {
+		t.Fatalf("expected vars %v, got %v", expected, stmt.Vars)
+	}
+	for i := range expected {
+		if stmt.Vars[i] != expected[i] {
+			t.Errorf("expected var %d to be %v, got %v", i, expected[i], stmt.Vars[i])
+		}
+	}
+}
+
+func TestFilterValues_DeclarativeErrors(t *testing.T) {
+	type unknownColumn struct {
+		Name string `filter:"column:nickname;op:eq"`
+	}
+
+	gotDB, err := filterValues(testutils.SetupDryRunDB("sqlite"), nilo.Value(any(unknownColumn{Name: "batch"})))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var columnErr *where.ColumnError
+	if err := gotDB.Find(&[]testutils.UserDB{}).Error; !errors.As(err, &columnErr) || columnErr.Column != "nickname" {
+		t.Errorf("expected a column error for 'nickname', got %v", err)
+	}
+
+	type unknownOperator struct {
+		Name string `filter:"column:username;op:matches"`
+	}
+	if _, err := filterValues(db, nilo.Value(any(unknownOperator{Name: "batch"}))); err == nil || err.Error() != "'Name': 'op:matches' is not a valid filter operator" {
+		t.Errorf("expected an operator error, got %v", err)
+	}
+
+	type badBetween struct {
+		Range []int `filter:"column:id;op:between"`
+	}
+	if _, err := filterValues(db, nilo.Value(any(badBetween{Range: []int{1}}))); err == nil {
+		t.Error("expected an error for between with a single value")
+	}
+}
+
+func TestFilterValues_ZeroValues(t *testing.T) {
+	type zeroFilter struct {
+		Username *string             `filter:"column:username"`
+		ID       *int                `filter:"column:id"`
+		PersonID nilo.Option[uint]   `filter:"column:person_id"`
+		Password nilo.Option[string] `filter:"column:password"`
+		Count    int                 `filter:"column:id;op:gt;omitzero"`
+		Enable   bool                `filter:"users.username is not null = ?;omitzero"`
+		Name     string              `filter:"column:username;op:ne"`
+		Since    time.Time           `filter:"column:id;op:gte;omitzero"`
+		Zero     int                 `filter:"column:person_id;op:gte"`
+	}
+
+	zero := 0
+	tests := []struct {
+		name     string
+		filter   zeroFilter
+		expected string
+	}{
+		{
+			"Unset fields",
+			zeroFilter{PersonID: nilo.Nil[uint](), Password: nilo.Nil[string]()},
+			"SELECT * FROM `users` WHERE `users`.`person_id` >= 0",
+		},
+		{
+			"Pointers and options to zero values",
+			zeroFilter{ID: &zero, PersonID: nilo.Value[uint](0), Password: nilo.Value(""), Zero: 1},
+			"SELECT * FROM `users` WHERE `users`.`id` = 0 AND `users`.`person_id` = 0 AND `users`.`password` = \"\" AND `users`.`person_id` >= 1",
+		},
+		{
+			"Set values",
+			zeroFilter{Username: new(string), PersonID: nilo.Nil[uint](), Password: nilo.Nil[string](), Count: 2, Enable: true, Name: "x", Zero: 3},
+			"SELECT * FROM `users` WHERE `users`.`username` = \"\" AND `users`.`id` > 2 AND users.username is not null = true AND `users`.`username` <> \"x\" AND `users`.`person_id` >= 3",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dryRun := testutils.SetupDryRunDB("sqlite")
+			gotDB, err := filterValues(dryRun.Model(&testutils.UserDB{}), nilo.Value(any(tt.filter)))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			stmt := gotDB.Find(&[]map[string]any{}).Statement
+			if sql := dryRun.Dialector.Explain(stmt.SQL.String(), stmt.Vars...); sql != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, sql)
+			}
+		})
+	}
+}
+
+type pagingFilter struct {
+	MinID int `filter:"column:id;op:gte;omitzero"`
+}
+
+type searchFilter struct {
+	Username string `filter:"column:username;op:contains"`
+	Email    string `filter:"column:persons.email;op:contains;join:inner join persons on persons.id = users.person_id"`
+}
+
+func TestFilterValues_NestedAndGroups(t *testing.T) {
+	type nestedFilter struct {
+		pagingFilter
+		Search   searchFilter  `filter:"group:search"`
+		Optional *searchFilter `filter:"group:optional"`
+		Name     string        `filter:"column:username;op:startswith;group:names"`
+		Nick     string        `filter:"username like ?;group:names"`
+		Password string        `filter:"column:password"`
+	}
+
+	tests := []struct {
+		name     string
+		filter   nestedFilter
+		expected string
+	}{
+		{
+			"Groups and embedded struct",
+			nestedFilter{
+				pagingFilter: pagingFilter{MinID: 5},
+				Search:       searchFilter{Username: "bat", Email: "bat"},
+				Name:         "b",
+				Nick:         "%x",
+				Password:     "1234",
+			},
+			"SELECT `users`.`id`,`users`.`username`,`users`.`password`,`users`.`person_id` FROM `users` inner join persons on persons.id = users.person_id " +
+				"WHERE `users`.`id` >= 5 AND `users`.`password` = \"1234\" " +
+				"AND (`users`.`username` like \"%bat%\" escape '!' OR `persons`.`email` like \"%bat%\" escape '!') " +
+				"AND (`users`.`username` like \"b%\" escape '!' OR username like \"%x\")",
+		},
+		{
+			"Unset groups and nil nested struct",
+			nestedFilter{Password: "1234"},
+			"SELECT * FROM `users` WHERE `users`.`password` = \"1234\"",
+		},
+		{
+			"Pointer to nested struct",
+			nestedFilter{Optional: &searchFilter{Username: "bat"}},
+			"SELECT * FROM `users` WHERE (`users`.`username` like \"%bat%\" escape '!')",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dryRun := testutils.SetupDryRunDB("sqlite")
+			gotDB, err := filterValues(dryRun, nilo.Value(any(tt.filter)))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			stmt := gotDB.Find(&[]testutils.UserDB{}).Statement
+			if sql := dryRun.Dialector.Explain(stmt.SQL.String(), stmt.Vars...); sql != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, sql)
+			}
+		})
+	}
+
+	type untaggedField struct {
+		Search searchFilter
+		Name   string
+	}
+	if _, err := filterValues(db, nilo.Value(any(untaggedField{Name: "x"}))); err == nil {
+		t.Error("expected error for a field without filter tag")
+	}
+}
+
+func TestFilterValues_DuplicatedJoins(t *testing.T) {
+	type joinedFilter struct {
+		Email string `filter:"column:Person.email;join:Person"`
+		Name  string `filter:"column:Person.name;join:Person"`
+		ID    uint   `filter:"p.id > ?;join:inner join persons p on p.id = users.person_id"`
+		Min   uint   `filter:"p.id >= ?;join:INNER JOIN persons p on p.id = users.person_id "`
+	}
+
+	dryRun := testutils.SetupDryRunDB("sqlite")
+	gotDB, err := filterValues(dryRun.Joins("Person"), nilo.Value(any(joinedFilter{Email: "b1@mail.com", Name: "Batch 1", ID: 1, Min: 1})))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	stmt := gotDB.Find(&[]testutils.UserDB{}).Statement
+	expected := "SELECT `users`.`id`,`users`.`username`,`users`.`password`,`users`.`person_id`," +
+		"`Person`.`id` AS `Person__id`,`Person`.`name` AS `Person__name`,`Person`.`email` AS `Person__email` FROM `users` " +
+		"LEFT JOIN `persons` `Person` ON `users`.`person_id` = `Person`.`id` inner join persons p on p.id = users.person_id " +
+		"WHERE `Person`.`email` = ? AND `Person`.`name` = ? AND p.id > ? AND p.id >= ?"
+	if sql := stmt.SQL.String(); sql != expected {
+		t.Errorf("expected %q, got %q", expected, sql)
+	}
+}
@@ -59,8 +59,8 @@ func (p *pageRequest) paginate(db *gorm.DB) *gorm.DB {
 
 // order applies sorting orders to the GORM DB query.
 func (p *pageRequest) order(db *gorm.DB) *gorm.DB {
-	for _, o := range p.sortOrders {
-		db = db.Order(o.Get())
+	if len(p.sortOrders) > 0 {
+		db = db.Order(sort.OrderBy(p.sortOrders))
 	}
 	return db
 }
@@ -90,6 +90,20 @@ func WithSortOrder(by string, direction sort.Direction) PageOptions {
 	}
 }
 
+// WithOrder adds sorting orders to the pageRequest, such as the order of a typed field or JSON path
+// or the orders parsed from a request.
+func WithOrder(orders ...sort.Order) PageOptions {
+	return func(p *pageRequest) error {
+		for _, order := range orders {
+			if err := order.IsValid(); err != nil {
+				return err
+			}
+		}
+		p.sortOrders = append(p.sortOrders, orders...)
+		return nil
+	}
+}
+
 // WithFilter adds a filter struct to the pageRequest.
 func WithFilter(filter any) PageOptions {
 	return func(p *pageRequest) error {
@@ -0,0 +1,160 @@
+package pagination
+
+import (
+	"fmt"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/javiorfo/gormen/pagination/sort"
+)
+
+// QueryConfig configures how PageRequestFromQuery reads the query parameters of a request.
+// Zero values take the defaults of DefaultQueryConfig.
+type QueryConfig struct {
+	// Name of the page number parameter, "pageNumber" by default
+	PageNumberParam string
+	// Name of the page size parameter, "pageSize" by default
+	PageSizeParam string
+	// Name of the repeatable sort parameter, "sort" by default
+	SortParam string
+	// Page size used when the parameter is missing, 10 by default
+	DefaultPageSize int
+	// Greatest page size allowed, no limit if 0
+	MaxPageSize int
+	// Whether a page size over MaxPageSize is lowered to it instead of failing
+	ClampPageSize bool
+	// Orders used when no sort parameter is given, an empty non-nil slice for none
+	DefaultSort []sort.Order
+	// Columns that can be sorted by, any column name if empty
+	Sortable []string
+}
+
+// DefaultQueryConfig returns a QueryConfig reading pageNumber, pageSize and sort,
+// with pages of 10 items sorted by "id" in ascending order.
+func DefaultQueryConfig() QueryConfig {
+	return QueryConfig{
+		PageNumberParam: "pageNumber",
+		PageSizeParam:   "pageSize",
+		SortParam:       "sort",
+		DefaultPageSize: 10,
+		DefaultSort:     []sort.Order{sort.Default()},
+	}
+}
+
+// PageRequestFromQuery constructs a pageRequest from the query parameters of a request, such as
+// ?pageNumber=2&pageSize=20&sort=name,desc&sort=id.
+// Each sort parameter holds one or more columns followed by an optional direction, asc by default,
+// and the orders are applied in the sequence given. Sort columns must be listed in config.Sortable, if set.
+// The options are applied as in PageRequestFrom, for instance to add a filter.
+// Unlike PageRequestFrom, any page number can be requested regardless of the page size.
+func PageRequestFromQuery(values url.Values, config QueryConfig, options ...PageOptions) (*pageRequest, error) {
+	defaults := DefaultQueryConfig()
+	if config.PageNumberParam == "" {
+		config.PageNumberParam = defaults.PageNumberParam
+	}
+	if config.PageSizeParam == "" {
+		config.PageSizeParam = defaults.PageSizeParam
+	}
+	if config.SortParam == "" {
+		config.SortParam = defaults.SortParam
+	}
+	if config.DefaultPageSize == 0 {
+		config.DefaultPageSize = defaults.DefaultPageSize
+	}
+	if config.DefaultSort == nil {
+		config.DefaultSort = defaults.DefaultSort
+	}
+
+	pageNumber := 1
+	if value := values.Get(config.PageNumberParam); value != "" {
+		number, err := strconv.Atoi(value)
+		if err != nil || number < 1 {
+			return nil, fmt.Errorf("'%s' must be a positive number", config.PageNumberParam)
+		}
+		pageNumber = number
+	}
+
+	pageSize := config.DefaultPageSize
+	if value := values.Get(config.PageSizeParam); value != "" {
+		size, err := strconv.Atoi(value)
+		if err != nil || size < 1 {
+			return nil, fmt.Errorf("'%s' must be a positive number greater than 0", config.PageSizeParam)
+		}
+		pageSize = size
+	}
+
+	if config.MaxPageSize > 0 && pageSize > config.MaxPageSize {
+		if !config.ClampPageSize {
+			return nil, fmt.Errorf("'%s' must not be greater than %d", config.PageSizeParam, config.MaxPageSize)
+		}
+		pageSize = config.MaxPageSize
+	}
+
+	orders := config.DefaultSort
+	if params := values[config.SortParam]; len(params) > 0 {
+		orders = nil
+		for _, param := range params {
+			parsed, err := parseSort(param, config)
+			if err != nil {
+				return nil, err
+			}
+			orders = append(orders, parsed...)
+		}
+	}
+
+	p := &pageRequest{pageNumber: pageNumber, pageSize: pageSize}
+	for _, opt := range append([]PageOptions{WithOrder(orders...)}, options...) {
+		if err := opt(p); err != nil {
+			return nil, err
+		}
+	}
+
+	return p, nil
+}
+
+// parseSort parses a sort parameter: columns separated by commas, the last element optionally
+// being the direction of all of them.
+func parseSort(param string, config QueryConfig) ([]sort.Order, error) {
+	columns := strings.Split(param, ",")
+	direction := sort.Ascending
+
+	if last := strings.TrimSpace(columns[len(columns)-1]); len(columns) > 1 && (strings.EqualFold(last, sort.Ascending) || strings.EqualFold(last, sort.Descending)) {
+		direction = sort.DirectionFromString(last)
+		columns = columns[:len(columns)-1]
+	}
+
+	orders := make([]sort.Order, len(columns))
+	for i, column := range columns {
+		column = strings.TrimSpace(column)
+		if !isColumnName(column) {
+			return nil, fmt.Errorf("'%s' has an invalid column '%s'", config.SortParam, column)
+		}
+		if len(config.Sortable) > 0 && !slices.Contains(config.Sortable, column) {
+			return nil, fmt.Errorf("'%s' does not allow sorting by '%s'", config.SortParam, column)
+		}
+		orders[i] = sort.NewOrder(column, direction)
+	}
+	return orders, nil
+}
+
+// isColumnName reports whether the value is a column name, optionally qualified by a table,
+// so that it can be written into the ORDER BY clause as is.
+func isColumnName(value string) bool {
+	if value == "" {
+		return false
+	}
+	for _, part := range strings.Split(value, ".") {
+		if part == "" || unicode.IsDigit(rune(part[0])) {
+			return false
+		}
+		for _, r := range part {
+			if r != '_' && !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+				return false
+			}
+		}
+	}
+	return true
+}
@@ -0,0 +1,120 @@
+package pagination
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/javiorfo/gormen/pagination/sort"
+)
+
+func TestPageRequestFromQuery(t *testing.T) {
+	values := url.Values{
+		"pageNumber": {"2"},
+		"pageSize":   {"20"},
+		"sort":       {"name,desc", "id", "created_at,updated_at,ASC"},
+	}
+
+	p, err := PageRequestFromQuery(values, QueryConfig{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if p.pageNumber != 2 || p.pageSize != 20 {
+		t.Errorf("expected page 2 of size 20, got page %d of size %d", p.pageNumber, p.pageSize)
+	}
+
+	expected := []sort.Order{
+		sort.NewOrder("name", sort.Descending),
+		sort.NewOrder("id", sort.Ascending),
+		sort.NewOrder("created_at", sort.Ascending),
+		sort.NewOrder("updated_at", sort.Ascending),
+	}
+	if len(p.sortOrders) != len(expected) {
+		t.Fatalf("expected %d orders, got %d", len(expected), len(p.sortOrders))
+	}
+	for i, o := range expected {
+		if p.sortOrders[i].Get() != o.Get() {
+			t.Errorf("expected order %q, got %q", o.Get(), p.sortOrders[i].Get())
+		}
+	}
+}
+
+func TestPageRequestFromQuery_Defaults(t *testing.T) {
+	p, err := PageRequestFromQuery(url.Values{}, QueryConfig{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if p.pageNumber != 1 || p.pageSize != 10 || len(p.sortOrders) != 1 || p.sortOrders[0].Get() != "id asc" {
+		t.Errorf("unexpected defaults: page %d, size %d, orders %v", p.pageNumber, p.pageSize, p.sortOrders)
+	}
+
+	config := QueryConfig{
+		PageNumberParam: "page",
+		PageSizeParam:   "size",
+		SortParam:       "orderBy",
+		DefaultPageSize: 25,
+		DefaultSort:     []sort.Order{},
+	}
+	p, err = PageRequestFromQuery(url.Values{"page": {"3"}, "pageNumber": {"x"}}, config)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if p.pageNumber != 3 || p.pageSize != 25 || len(p.sortOrders) != 0 {
+		t.Errorf("unexpected config: page %d, size %d, orders %v", p.pageNumber, p.pageSize, p.sortOrders)
+	}
+}
+
+func TestPageRequestFromQuery_MaxPageSize(t *testing.T) {
+	values := url.Values{"pageSize": {"500"}}
+
+	if _, err := PageRequestFromQuery(values, QueryConfig{MaxPageSize: 100}); err == nil || err.Error() != "'pageSize' must not be greater than 100" {
+		t.Errorf("expected max page size error, got %v", err)
+	}
+
+	p, err := PageRequestFromQuery(values, QueryConfig{MaxPageSize: 100, ClampPageSize: true})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if p.pageSize != 100 {
+		t.Errorf("expected page size clamped to 100, got %d", p.pageSize)
+	}
+}
+
+func TestPageRequestFromQuery_Invalid(t *testing.T) {
+	tests := []struct {
+		name   string
+		values url.Values
+		config QueryConfig
+	}{
+		{"Page number not a number", url.Values{"pageNumber": {"one"}}, QueryConfig{}},
+		{"Page number zero", url.Values{"pageNumber": {"0"}}, QueryConfig{}},
+		{"Page size zero", url.Values{"pageSize": {"0"}}, QueryConfig{}},
+		{"Sort injection", url.Values{"sort": {"id; drop table users"}}, QueryConfig{}},
+		{"Sort empty column", url.Values{"sort": {",desc"}}, QueryConfig{}},
+		{"Sort not allowed", url.Values{"sort": {"password"}}, QueryConfig{Sortable: []string{"id", "name"}}},
+		{"Option error", url.Values{}, QueryConfig{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var options []PageOptions
+			if tt.name == "Option error" {
+				options = append(options, WithFilter("not a struct"))
+			}
+			if _, err := PageRequestFromQuery(tt.values, tt.config, options...); err == nil {
+				t.Error("expected error")
+			}
+		})
+	}
+}
+
+func TestPageRequestFromQuery_PageNumberOverPageSize(t *testing.T) {
+	p, err := PageRequestFromQuery(url.Values{"pageNumber": {"11"}}, QueryConfig{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if p.pageNumber != 11 || p.pageSize != 10 {
+		t.Errorf("expected page 11 of size 10, got page %d of size %d", p.pageNumber, p.pageSize)
+	}
+}
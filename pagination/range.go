@@ -0,0 +1,117 @@
+package pagination
+
+import (
+	"net/url"
+	"reflect"
+
+	"github.com/javiorfo/gormen/where"
+	"github.com/javiorfo/nilo"
+)
+
+// Range is a filter field type matching values between two optional bounds, such as a date or numeric range.
+// Used in a filter struct with a declarative tag, e.g. `filter:"column:created_at"`, each set bound adds
+// a predicate on the column; a Range without bounds does not filter.
+// Bounds are inclusive unless marked as exclusive.
+type Range[T any] struct {
+	// Lower bound, unset for no lower bound
+	From nilo.Option[T]
+	// Upper bound, unset for no upper bound
+	To nilo.Option[T]
+	// Whether values equal to From are excluded
+	ExclusiveFrom bool
+	// Whether values equal to To are excluded
+	ExclusiveTo bool
+}
+
+// ClosedRange returns a Range including both bounds.
+func ClosedRange[T any](from, to T) Range[T] {
+	return Range[T]{From: nilo.Value(from), To: nilo.Value(to)}
+}
+
+// OpenRange returns a Range excluding both bounds.
+func OpenRange[T any](from, to T) Range[T] {
+	return Range[T]{From: nilo.Value(from), To: nilo.Value(to), ExclusiveFrom: true, ExclusiveTo: true}
+}
+
+// HalfOpenRange returns a Range including the lower bound and excluding the upper one,
+// the usual range for dates and times.
+func HalfOpenRange[T any](from, to T) Range[T] {
+	return Range[T]{From: nilo.Value(from), To: nilo.Value(to), ExclusiveTo: true}
+}
+
+// RangeFrom returns a Range with only an inclusive lower bound.
+func RangeFrom[T any](from T) Range[T] {
+	return Range[T]{From: nilo.Value(from)}
+}
+
+// RangeTo returns a Range with only an inclusive upper bound.
+func RangeTo[T any](to T) Range[T] {
+	return Range[T]{To: nilo.Value(to)}
+}
+
+// IsSet reports whether the Range has any bound.
+func (r Range[T]) IsSet() bool {
+	return r.From.IsValue() || r.To.IsValue()
+}
+
+// Condition returns the condition matching the values of the column within the Range:
+// BETWEEN when both bounds are inclusive, otherwise a comparison for each set bound.
+// A Range without bounds matches any value.
+func (r Range[T]) Condition(column where.ColumnName) where.Condition {
+	if r.From.IsValue() && r.To.IsValue() && !r.ExclusiveFrom && !r.ExclusiveTo {
+		return where.Between(column, r.From.AsValue(), r.To.AsValue())
+	}
+
+	var conditions []where.Condition
+	r.From.Consume(func(from T) {
+		if r.ExclusiveFrom {
+			conditions = append(conditions, where.GreaterThan(column, from))
+		} else {
+			conditions = append(conditions, where.GreaterOrEqual(column, from))
+		}
+	})
+	r.To.Consume(func(to T) {
+		if r.ExclusiveTo {
+			conditions = append(conditions, where.LessThan(column, to))
+		} else {
+			conditions = append(conditions, where.LessOrEqual(column, to))
+		}
+	})
+
+	if len(conditions) == 1 {
+		return conditions[0]
+	}
+	return where.AllOf(conditions...)
+}
+
+// bind sets the bounds of the Range from the parameters named after the given one with
+// the .from and .to suffixes, reporting conversion errors by parameter name.
+func (r *Range[T]) bind(param string, values url.Values, paramErrors ParamErrors) {
+	bound := func(name string, option *nilo.Option[T]) {
+		params, ok := values[name]
+		if !ok || len(params) == 0 {
+			return
+		}
+
+		var value T
+		if err := bindValue(reflect.ValueOf(&value).Elem(), params); err != nil {
+			paramErrors[name] = err
+			return
+		}
+		*option = nilo.Value(value)
+	}
+
+	bound(param+".from", &r.From)
+	bound(param+".to", &r.To)
+}
+
+// rangeFilter is satisfied by Range values of any type.
+type rangeFilter interface {
+	IsSet() bool
+	Condition(where.ColumnName) where.Condition
+}
+
+// rangeBinder is satisfied by pointers to Range values of any type.
+type rangeBinder interface {
+	bind(string, url.Values, ParamErrors)
+}
@@ -0,0 +1,110 @@
+package pagination
+
+import (
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/javiorfo/gormen/internal/testutils"
+	"github.com/javiorfo/nilo"
+)
+
+func TestRange_Condition(t *testing.T) {
+	tests := []struct {
+		name     string
+		r        Range[int]
+		expected string
+	}{
+		{"Closed", ClosedRange(1, 9), "SELECT * FROM `users` WHERE `users`.`id` between 1 and 9"},
+		{"Open", OpenRange(1, 9), "SELECT * FROM `users` WHERE (`users`.`id` > 1 AND `users`.`id` < 9)"},
+		{"Half open", HalfOpenRange(1, 9), "SELECT * FROM `users` WHERE (`users`.`id` >= 1 AND `users`.`id` < 9)"},
+		{"From", RangeFrom(1), "SELECT * FROM `users` WHERE `users`.`id` >= 1"},
+		{"To", RangeTo(9), "SELECT * FROM `users` WHERE `users`.`id` <= 9"},
+		{"Exclusive to only", Range[int]{To: nilo.Value(9), ExclusiveTo: true}, "SELECT * FROM `users` WHERE `users`.`id` < 9"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dryRun := testutils.SetupDryRunDB("sqlite")
+			stmt := dryRun.Where(tt.r.Condition("id")).Find(&[]testutils.UserDB{}).Statement
+			if sql := dryRun.Dialector.Explain(stmt.SQL.String(), stmt.Vars...); sql != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, sql)
+			}
+		})
+	}
+
+	if (Range[int]{}).IsSet() || !RangeTo(0).IsSet() {
+		t.Error("unexpected IsSet")
+	}
+}
+
+type rangedFilter struct {
+	IDs     Range[uint]       `param:"id" filter:"column:id"`
+	Created *Range[time.Time] `param:"created" filter:"column:persons.id;join:inner join persons on persons.id = users.person_id"`
+	Persons Range[uint]       `filter:"column:person_id;group:range"`
+}
+
+func TestFilterValues_Range(t *testing.T) {
+	from := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		filter   rangedFilter
+		expected string
+	}{
+		{"Unset", rangedFilter{}, "SELECT * FROM `users`"},
+		{
+			"Bounds",
+			rangedFilter{IDs: RangeFrom[uint](2), Persons: OpenRange[uint](1, 5)},
+			"SELECT * FROM `users` WHERE `users`.`id` >= 2 AND ((`users`.`person_id` > 1 AND `users`.`person_id` < 5))",
+		},
+		{
+			"Time and join",
+			rangedFilter{Created: &Range[time.Time]{From: nilo.Value(from)}},
+			"SELECT `users`.`id`,`users`.`username`,`users`.`password`,`users`.`person_id` FROM `users` inner join persons on persons.id = users.person_id " +
+				"WHERE `persons`.`id` >= \"2025-01-01 00:00:00\"",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dryRun := testutils.SetupDryRunDB("sqlite")
+			gotDB, err := filterValues(dryRun, nilo.Value(any(tt.filter)))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			stmt := gotDB.Find(&[]testutils.UserDB{}).Statement
+			if sql := dryRun.Dialector.Explain(stmt.SQL.String(), stmt.Vars...); sql != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, sql)
+			}
+		})
+	}
+
+	type rawRange struct {
+		IDs Range[int] `filter:"id between ? and ?"`
+	}
+	if _, err := filterValues(db, nilo.Value(any(rawRange{IDs: ClosedRange(1, 2)}))); err == nil {
+		t.Error("expected error for a Range without column")
+	}
+}
+
+func TestBindFilter_Range(t *testing.T) {
+	values := url.Values{"id.from": {"3"}, "created.to": {"2025-02-01"}}
+
+	filter, err := BindFilter[rangedFilter](values)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if filter.IDs.From.OrDefault() != 3 || filter.IDs.To.IsValue() {
+		t.Errorf("unexpected ids %+v", filter.IDs)
+	}
+	if filter.Created == nil || !filter.Created.To.OrDefault().Equal(time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("unexpected created %+v", filter.Created)
+	}
+
+	_, err = BindFilter[rangedFilter](url.Values{"id.to": {"x"}})
+	if paramErrors, ok := err.(ParamErrors); !ok || paramErrors["id.to"] == nil {
+		t.Errorf("expected an error for 'id.to', got %v", err)
+	}
+}
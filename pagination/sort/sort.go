@@ -4,6 +4,8 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+
+	"gorm.io/gorm/clause"
 )
 
 // Order represents sorting criteria with a column and direction.
@@ -12,6 +14,8 @@ type Order struct {
 	by string
 	// Sort direction, either Ascending or Descending
 	direction Direction
+	// Optional expression to sort by instead of the column, such as a JSON path
+	expression clause.Expression
 }
 
 // By returns the column name used for ordering.
@@ -29,6 +33,15 @@ func (o Order) Get() string {
 	return fmt.Sprintf("%s %s", o.By(), o.Direction())
 }
 
+// Expression returns the Order as a Gorm clause expression, sorting by its expression if any,
+// otherwise by the column as given.
+func (o Order) Expression() clause.Expression {
+	if o.expression == nil {
+		return clause.Expr{SQL: o.Get()}
+	}
+	return clause.Expr{SQL: "? " + o.direction, Vars: []any{o.expression}}
+}
+
 // IsValid validates the Order, ensuring direction is 'asc' or 'desc' and column is not empty.
 func (o Order) IsValid() error {
 	if o.direction != Ascending && o.direction != Descending {
@@ -49,7 +62,23 @@ func Default() Order {
 
 // NewOrder creates a new Order with the given column and direction.
 func NewOrder(by string, direction Direction) Order {
-	return Order{by, direction}
+	return Order{by, direction, nil}
+}
+
+// NewExpressionOrder creates a new Order by a Gorm clause expression, such as a JSON path,
+// described by the given name.
+func NewExpressionOrder(by string, expression clause.Expression, direction Direction) Order {
+	return Order{by, direction, expression}
+}
+
+// OrderBy returns a Gorm ORDER BY clause sorting by the given orders in sequence.
+// The clause replaces any order previously added to the query.
+func OrderBy(orders []Order) clause.OrderBy {
+	exprs := make([]clause.Expression, len(orders))
+	for i, o := range orders {
+		exprs[i] = o.Expression()
+	}
+	return clause.OrderBy{Expression: clause.CommaExpression{Exprs: exprs}}
 }
 
 // Direction defines the sorting direction as a string type.
@@ -51,4 +51,6 @@ type ReadRepository[M any] interface {
 	FindAllPaginatedBy(ctx context.Context, pageable pagination.Pageable, where Where, preloads ...Preload) (*pagination.Page[M], error)
 	// FindAllOrdered returns all records ordered by given sort criteria.
 	FindAllOrdered(ctx context.Context, orders []sort.Order, preloads ...Preload) ([]M, error)
+	// Explain renders, without running them, the statements FindAllPaginatedBy runs for the same arguments.
+	Explain(ctx context.Context, pageable pagination.Pageable, where Where, preloads ...Preload) (*Explanation, error)
 }
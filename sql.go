@@ -1,8 +1,14 @@
 package gormen
 
 import (
+	"iter"
+	"slices"
+
 	"github.com/javiorfo/gormen/internal/types"
+	"github.com/javiorfo/gormen/internal/utils"
 	"github.com/javiorfo/gormen/where"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 // Preload represents a string identifier for preloading related entities in Gorm
@@ -11,23 +17,44 @@ type Preload = string
 // Join represents a string identifier for SQL join operations in Gorm
 type Join = string
 
-// conditions maps Condition instances to their logical operator type (e.g., AND, OR).
-type conditions = map[where.Condition]int
+// condition pairs a where.Condition with the logical operator type (e.g., AND, OR)
+// that combines it with the conditions added before it.
+type condition struct {
+	condition where.Condition
+	operator  int
+}
 
 // Where holds SQL query conditions and join clauses to build complex queries.
+// Conditions are kept in insertion order, so the same Where always renders the same SQL.
 type Where struct {
-	conditions conditions
+	conditions []condition
 	joins      []Join
 }
 
-// NewWhere creates a new Where instance with an initial condition and no logical operator.
-func NewWhere(c where.Condition) *Where {
-	return &Where{conditions: conditions{c: types.None}}
+// NewWhere creates a new Where instance with the given conditions combined with a logical AND.
+// The first condition has no logical operator; with no conditions it starts an empty Where,
+// which matches every record until conditions are added.
+func NewWhere(conditions ...where.Condition) *Where {
+	w := &Where{}
+	for i, c := range conditions {
+		if i == 0 {
+			w.conditions = append(w.conditions, condition{c, types.None})
+			continue
+		}
+		w.And(c)
+	}
+	return w
 }
 
-// Conditions returns the map of conditions and their logical operators.
-func (w Where) Conditions() conditions {
-	return w.conditions
+// Conditions returns an iterator over the conditions, in insertion order, and their logical operators.
+func (w Where) Conditions() iter.Seq2[where.Condition, int] {
+	return func(yield func(where.Condition, int) bool) {
+		for _, c := range w.conditions {
+			if !yield(c.condition, c.operator) {
+				return
+			}
+		}
+	}
 }
 
 // Joins returns the list of join clauses included in the query.
@@ -36,17 +63,79 @@ func (w Where) Joins() []Join {
 }
 
 // And adds a condition combined with a logical AND to the Where clause.
+// The first condition of an empty Where has no logical operator, as in NewWhere.
 func (w *Where) And(c where.Condition) *Where {
-	w.conditions[c] = types.And
-	return w
+	return w.add(c, types.And)
 }
 
 // Or adds a condition combined with a logical OR to the Where clause.
+// The first condition of an empty Where has no logical operator, as in NewWhere,
+// so a later AND is not turned into an OR.
 func (w *Where) Or(c where.Condition) *Where {
-	w.conditions[c] = types.Or
+	return w.add(c, types.Or)
+}
+
+// add appends the condition with the logical operator, or with none if it is the first one.
+func (w *Where) add(c where.Condition, operator int) *Where {
+	if len(w.conditions) == 0 {
+		operator = types.None
+	}
+	w.conditions = append(w.conditions, condition{c, operator})
 	return w
 }
 
+// AndIf adds a condition combined with a logical AND only if the predicate is true.
+func (w *Where) AndIf(predicate bool, c where.Condition) *Where {
+	if predicate {
+		w.And(c)
+	}
+	return w
+}
+
+// OrIf adds a condition combined with a logical OR only if the predicate is true.
+func (w *Where) OrIf(predicate bool, c where.Condition) *Where {
+	if predicate {
+		w.Or(c)
+	}
+	return w
+}
+
+// Merge combines the conditions of another Where, as a parenthesized group, with a logical AND
+// and adds its join clauses not already included.
+// The other Where keeps its own AND/OR semantics inside the group, also when merged into an empty Where,
+// so conditions added afterwards do not change its precedence. The conditions of the receiver are
+// grouped too when they contain an OR, so the merged ones restrict all of them.
+func (w *Where) Merge(other Where) *Where {
+	for _, join := range other.joins {
+		if !slices.Contains(w.joins, join) {
+			w.joins = append(w.joins, join)
+		}
+	}
+
+	var merged where.Condition
+	switch len(other.conditions) {
+	case 0:
+		return w
+	case 1:
+		merged = other.conditions[0].condition
+	default:
+		merged = group(slices.Clone(other.conditions))
+	}
+
+	if len(w.conditions) > 1 && slices.ContainsFunc(w.conditions, func(c condition) bool { return c.operator == types.Or }) {
+		w.conditions = []condition{{group(w.conditions), types.None}}
+	}
+
+	return w.And(merged)
+}
+
+// Clone returns a new Where builder with a copy of the conditions and join clauses,
+// so further changes to either of them do not affect the other.
+func (w *Where) Clone() *Where {
+	clone := w.Build()
+	return &clone
+}
+
 // WithJoin sets the list of join clauses to include in the Where query.
 func (w *Where) WithJoin(joins ...Join) *Where {
 	w.joins = joins
@@ -55,5 +144,47 @@ func (w *Where) WithJoin(joins ...Join) *Where {
 
 // Build finalizes and returns a copy of the Where instance.
 func (w *Where) Build() Where {
-	return *w
+	return Where{conditions: slices.Clone(w.conditions), joins: slices.Clone(w.joins)}
+}
+
+// Apply adds the join clauses and the conditions, in insertion order, to the GORM DB query.
+// Joins the query already has, such as the same association, are not added again.
+// Its signature matches gorm.DB.Scopes, so it can also be used as a scope.
+func (w Where) Apply(db *gorm.DB) *gorm.DB {
+	for _, join := range w.joins {
+		if !utils.HasJoin(db, join) {
+			db = db.Joins(join)
+		}
+	}
+
+	for cond, op := range w.Conditions() {
+		switch op {
+		case types.Or:
+			db = db.Or(cond)
+		default:
+			db = db.Where(cond)
+		}
+	}
+
+	return db
+}
+
+// group represents a parenthesized sequence of conditions combined by their logical operators.
+type group []condition
+
+// Build writes the conditions inside parentheses, joined by AND or OR as added.
+// Satisfies where.Condition interface
+func (g group) Build(builder clause.Builder) {
+	builder.WriteByte('(')
+	for i, c := range g {
+		if i > 0 {
+			if c.operator == types.Or {
+				builder.WriteString(clause.OrWithSpace)
+			} else {
+				builder.WriteString(clause.AndWithSpace)
+			}
+		}
+		c.condition.Build(builder)
+	}
+	builder.WriteByte(')')
 }
@@ -0,0 +1,181 @@
+package gormen
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/javiorfo/gormen/internal/testutils"
+	"github.com/javiorfo/gormen/internal/types"
+	"github.com/javiorfo/gormen/where"
+	"gorm.io/gorm"
+)
+
+func TestWhere_ConditionsKeepInsertionOrder(t *testing.T) {
+	first := where.Equal("username", "jdoe")
+	w := NewWhere(first).And(first).Or(where.In("id", []int{1, 2})).Build()
+
+	var operators []int
+	for _, op := range w.Conditions() {
+		operators = append(operators, op)
+	}
+
+	expected := []int{types.None, types.And, types.Or}
+	if len(operators) != len(expected) {
+		t.Fatalf("expected %d conditions, got %d", len(expected), len(operators))
+	}
+
+	for i := range expected {
+		if operators[i] != expected[i] {
+			t.Errorf("condition %d: expected operator %d, got %d", i, expected[i], operators[i])
+		}
+	}
+}
+
+func TestWhere_BuildDoesNotShareState(t *testing.T) {
+	builder := NewWhere(where.Equal("username", "jdoe"))
+	w := builder.Build()
+	builder.And(where.Equal("password", "1234"))
+
+	count := 0
+	for range w.Conditions() {
+		count++
+	}
+
+	if count != 1 {
+		t.Errorf("expected built Where to keep 1 condition, got %d", count)
+	}
+}
+
+func TestWhere_ApplyRendersSameSQL(t *testing.T) {
+	db := testutils.SetupTestDB().Session(&gorm.Session{DryRun: true})
+
+	w := NewWhere(where.Equal("username", "jdoe")).
+		And(where.GreaterThan("id", 1)).
+		Or(where.In("id", []int{1, 2})).
+		Build()
+
+	expected := "SELECT * FROM `users` WHERE `users`.`username` = ? AND `users`.`id` > ? OR `users`.`id` in (?,?)"
+	for range 10 {
+		stmt := db.Scopes(w.Apply).Find(&[]testutils.UserDB{}).Statement
+		if sql := stmt.SQL.String(); sql != expected {
+			t.Fatalf("expected %q, got %q", expected, sql)
+		}
+	}
+}
+
+func TestWhere_ToSQL(t *testing.T) {
+	db := testutils.SetupTestDB()
+
+	w := NewWhere(where.Equal("persons.email", "jdoe@mail.com")).
+		And(where.In("id", []int{1, 2})).
+		WithJoin("inner join persons on users.person_id = persons.id").
+		Build()
+
+	statement, err := w.ToSQL(db, &testutils.UserDB{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := "SELECT `users`.`id`,`users`.`username`,`users`.`password`,`users`.`person_id` FROM `users` " +
+		"inner join persons on users.person_id = persons.id WHERE `persons`.`email` = ? AND `users`.`id` in (?,?)"
+	if statement.SQL != expected {
+		t.Fatalf("expected %q, got %q", expected, statement.SQL)
+	}
+
+	if len(statement.Vars) != 3 {
+		t.Fatalf("expected 3 vars, got %v", statement.Vars)
+	}
+
+	explained := "SELECT `users`.`id`,`users`.`username`,`users`.`password`,`users`.`person_id` FROM `users` " +
+		"inner join persons on users.person_id = persons.id WHERE `persons`.`email` = \"jdoe@mail.com\" AND `users`.`id` in (1,2)"
+	if statement.String() != explained {
+		t.Errorf("expected %q, got %q", explained, statement.String())
+	}
+
+	var count int64
+	if err := db.Model(&testutils.UserDB{}).Count(&count).Error; err != nil || count != 0 {
+		t.Errorf("expected no records to be created, got %d (%v)", count, err)
+	}
+
+	_, err = NewWhere(where.Equal("unknown", 1)).Build().ToSQL(db, &testutils.UserDB{})
+	var columnErr *where.ColumnError
+	if !errors.As(err, &columnErr) {
+		t.Errorf("expected column error, got %v", err)
+	}
+}
+
+func TestWhere_ComposableBuilders(t *testing.T) {
+	db := testutils.SetupDryRunDB("sqlite")
+
+	var zero Where
+	zero.And(where.Equal("username", "jdoe"))
+
+	name, enabled := "", true
+	empty := NewWhere().
+		AndIf(name != "", where.Equal("username", name)).
+		AndIf(enabled, where.Equal("password", "1234")).
+		OrIf(false, where.Equal("id", 1))
+
+	base := NewWhere(where.Equal("id", 1), where.Equal("id", 2)).WithJoin("inner join persons on users.person_id = persons.id")
+	clone := base.Clone().Or(where.Equal("id", 3))
+	merged := base.Clone().Merge(NewWhere(where.Equal("username", "jdoe")).Or(where.Equal("username", "batch1")).
+		WithJoin("inner join persons on users.person_id = persons.id").Build())
+	mergedOr := NewWhere(where.Equal("username", "a")).Or(where.Equal("username", "b")).Merge(NewWhere(where.Equal("person_id", 7)).Build())
+	leadingOr := NewWhere().
+		AndIf(false, where.Equal("id", 1)).
+		OrIf(true, where.Equal("username", "a")).
+		AndIf(true, where.Equal("password", "p"))
+	mergedEmpty := NewWhere().Merge(NewWhere(where.Equal("id", 1)).Or(where.Equal("id", 2)).Build()).And(where.Equal("username", "x"))
+
+	tests := []struct {
+		name     string
+		where    Where
+		expected string
+	}{
+		{"Zero value", zero, "SELECT * FROM `users` WHERE `users`.`username` = ?"},
+		{"Empty builder", NewWhere().Build(), "SELECT * FROM `users`"},
+		{"Conditional", empty.Build(), "SELECT * FROM `users` WHERE `users`.`password` = ?"},
+		{"Base not changed by clones", base.Build(), "SELECT `users`.`id`,`users`.`username`,`users`.`password`,`users`.`person_id` FROM `users` " +
+			"inner join persons on users.person_id = persons.id WHERE `users`.`id` = ? AND `users`.`id` = ?"},
+		{"Clone", clone.Build(), "SELECT `users`.`id`,`users`.`username`,`users`.`password`,`users`.`person_id` FROM `users` " +
+			"inner join persons on users.person_id = persons.id WHERE `users`.`id` = ? AND `users`.`id` = ? OR `users`.`id` = ?"},
+		{"Merge", merged.Build(), "SELECT `users`.`id`,`users`.`username`,`users`.`password`,`users`.`person_id` FROM `users` " +
+			"inner join persons on users.person_id = persons.id WHERE `users`.`id` = ? AND `users`.`id` = ? " +
+			"AND (`users`.`username` = ? OR `users`.`username` = ?)"},
+		{"Merge into empty", NewWhere().Merge(clone.Build()).Build(), "SELECT `users`.`id`,`users`.`username`,`users`.`password`,`users`.`person_id` FROM `users` " +
+			"inner join persons on users.person_id = persons.id WHERE (`users`.`id` = ? AND `users`.`id` = ? OR `users`.`id` = ?)"},
+		{"Merge into empty then and", mergedEmpty.Build(), "SELECT * FROM `users` WHERE (`users`.`id` = ? OR `users`.`id` = ?) AND `users`.`username` = ?"},
+		{"Merge into or", mergedOr.Build(), "SELECT * FROM `users` WHERE (`users`.`username` = ? OR `users`.`username` = ?) AND `users`.`person_id` = ?"},
+		{"Or first", leadingOr.Build(), "SELECT * FROM `users` WHERE `users`.`username` = ? AND `users`.`password` = ?"},
+		{"Merge single condition", NewWhere().Merge(NewWhere(where.Equal("id", 1)).Build()).Build(), "SELECT * FROM `users` WHERE `users`.`id` = ?"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			statement, err := tt.where.ToSQL(db, &testutils.UserDB{})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if statement.SQL != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, statement.SQL)
+			}
+		})
+	}
+}
+
+func TestWhere_RawKeepsPrecedence(t *testing.T) {
+	w := NewWhere(where.Raw("username = @a OR username = @b", where.NamedArgs{"a": "x", "b": "y"})).
+		And(where.Equal("id", 1)).
+		Build()
+
+	statement, err := w.ToSQL(testutils.SetupTestDB(), &testutils.UserDB{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := "SELECT * FROM `users` WHERE (username = \"x\" OR username = \"y\") AND `users`.`id` = 1"
+	if statement.String() != expected {
+		t.Errorf("expected %q, got %q", expected, statement.String())
+	}
+}
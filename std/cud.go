@@ -67,20 +67,7 @@ func (repository *repository[M]) DeleteAll(ctx context.Context, models []M) erro
 
 // DeleteAllBy deletes all records matching the conditions and joins defined in the Where clause.
 func (repository repository[M]) DeleteAllBy(ctx context.Context, where gormen.Where) error {
-	query := repository.db.WithContext(ctx)
-
-	for _, join := range where.Joins() {
-		query = query.Joins(join)
-	}
-
-	for cond, op := range where.Conditions() {
-		switch op {
-		case types.Or:
-			query = query.Or(cond.Get())
-		default:
-			query = query.Where(cond.Get())
-		}
-	}
+	query := where.Apply(repository.db.WithContext(ctx))
 
 	// Delete all matching records of model type M
 	query = query.Delete(*new(M))
@@ -5,7 +5,6 @@ import (
 	"errors"
 
 	"github.com/javiorfo/gormen"
-	"github.com/javiorfo/gormen/internal/types"
 	"github.com/javiorfo/gormen/pagination"
 	"github.com/javiorfo/gormen/pagination/sort"
 	"github.com/javiorfo/nilo"
@@ -30,30 +29,11 @@ func (repository *repository[M]) FindAllPaginatedBy(ctx context.Context, pageabl
 		return &pagination.Page[M]{Total: total}, nil
 	}
 
-	query := repository.db.WithContext(ctx)
-
-	for _, preload := range preloads {
-		query = query.Preload(preload)
-	}
-
-	for _, join := range where.Joins() {
-		query = query.Joins(join)
-	}
-
-	page, err := pageable.Paginate(query)
+	page, err := repository.pageQuery(repository.db.WithContext(ctx), pageable, where, preloads...)
 	if err != nil {
 		return nil, err
 	}
 
-	for cond, op := range where.Conditions() {
-		switch op {
-		case types.Or:
-			query = query.Or(cond.Get())
-		default:
-			query = query.Where(cond.Get())
-		}
-	}
-
 	var entities []M
 	results := page.Find(&entities)
 	if err := results.Error; err != nil {
@@ -77,18 +57,7 @@ func (repository *repository[M]) FindAllBy(ctx context.Context, where gormen.Whe
 		query = query.Preload(preload)
 	}
 
-	for _, join := range where.Joins() {
-		query = query.Joins(join)
-	}
-
-	for cond, op := range where.Conditions() {
-		switch op {
-		case types.Or:
-			query = query.Or(cond.Get())
-		default:
-			query = query.Where(cond.Get())
-		}
-	}
+	query = where.Apply(query)
 
 	var entities []M
 	results := query.Find(&entities)
@@ -108,8 +77,8 @@ func (repository *repository[M]) FindAllOrdered(ctx context.Context, orders []so
 		query = query.Preload(preload)
 	}
 
-	for _, o := range orders {
-		query = query.Order(o.Get())
+	if len(orders) > 0 {
+		query = query.Order(sort.OrderBy(orders))
 	}
 
 	var entities []M
@@ -123,38 +92,69 @@ func (repository *repository[M]) FindAllOrdered(ctx context.Context, orders []so
 
 // count calculates the total number of records available based on the Pageable filtering and preloads.
 func (repository repository[M]) count(ctx context.Context, pageable pagination.Pageable, where gormen.Where, preloads ...gormen.Preload) (int64, error) {
-	query := repository.db.WithContext(ctx)
+	query, err := repository.countQuery(repository.db.WithContext(ctx), pageable, where, preloads...)
+	if err != nil {
+		return 0, err
+	}
 
-	for _, preload := range preloads {
-		query = query.Preload(preload)
+	var count int64
+	results := query.Count(&count)
+	if err := results.Error; err != nil {
+		return 0, err
 	}
 
-	for _, join := range where.Joins() {
-		query = query.Joins(join)
+	return count, nil
+}
+
+// countQuery builds, on top of the given GORM DB, the query counting the records
+// that match the Where clause and the Pageable filtering.
+func (repository repository[M]) countQuery(db *gorm.DB, pageable pagination.Pageable, where gormen.Where, preloads ...gormen.Preload) (*gorm.DB, error) {
+	for _, preload := range preloads {
+		db = db.Preload(preload)
 	}
 
-	for cond, op := range where.Conditions() {
-		if op == types.Or {
-			query = query.Or(cond.Get())
-		} else {
-			query = query.Where(cond.Get())
-		}
+	db = where.Apply(db).Model(*new(M))
+
+	return pageable.Filter(db)
+}
+
+// pageQuery builds, on top of the given GORM DB, the query selecting the page of records
+// that match the Where clause, applying the Pageable pagination, sorting and filtering.
+func (repository repository[M]) pageQuery(db *gorm.DB, pageable pagination.Pageable, where gormen.Where, preloads ...gormen.Preload) (*gorm.DB, error) {
+	for _, preload := range preloads {
+		db = db.Preload(preload)
 	}
 
-	query = query.Model(*new(M))
+	return pageable.Paginate(where.Apply(db))
+}
+
+// Explain renders, without running them, the count and page statements that FindAllPaginatedBy
+// runs for the same arguments, with their bound parameters.
+func (repository *repository[M]) Explain(ctx context.Context, pageable pagination.Pageable, where gormen.Where, preloads ...gormen.Preload) (*gormen.Explanation, error) {
+	db := repository.db.Session(&gorm.Session{DryRun: true, Context: ctx})
 
-	filteredQuery, err := pageable.Filter(query)
+	countQuery, err := repository.countQuery(db, pageable, where, preloads...)
 	if err != nil {
-		return 0, err
+		return nil, err
 	}
 
 	var count int64
-	results := filteredQuery.Count(&count)
-	if err := results.Error; err != nil {
-		return 0, err
+	countStatement, err := gormen.StatementOf(countQuery.Count(&count))
+	if err != nil {
+		return nil, err
 	}
 
-	return count, nil
+	pageQuery, err := repository.pageQuery(db, pageable, where, preloads...)
+	if err != nil {
+		return nil, err
+	}
+
+	pageStatement, err := gormen.StatementOf(pageQuery.Find(&[]M{}))
+	if err != nil {
+		return nil, err
+	}
+
+	return &gormen.Explanation{Count: countStatement, Page: pageStatement}, nil
 }
 
 // FindBy fetches the first record of type M matching the Where clause with preloads,
@@ -166,18 +166,7 @@ func (repository *repository[M]) FindBy(ctx context.Context, where gormen.Where,
 		query = query.Preload(preload)
 	}
 
-	for _, join := range where.Joins() {
-		query = query.Joins(join)
-	}
-
-	for cond, op := range where.Conditions() {
-		switch op {
-		case types.Or:
-			query = query.Or(cond.Get())
-		default:
-			query = query.Where(cond.Get())
-		}
-	}
+	query = where.Apply(query)
 
 	var entity M
 	result := query.First(&entity)
@@ -199,20 +188,7 @@ func (repository repository[_]) Count(ctx context.Context) (int64, error) {
 
 // CountBy returns the number of records matching the given Where clause.
 func (repository repository[M]) CountBy(ctx context.Context, where gormen.Where) (int64, error) {
-	query := repository.db.WithContext(ctx)
-
-	for _, join := range where.Joins() {
-		query = query.Joins(join)
-	}
-
-	for cond, op := range where.Conditions() {
-		switch op {
-		case types.Or:
-			query = query.Or(cond.Get())
-		default:
-			query = query.Where(cond.Get())
-		}
-	}
+	query := where.Apply(repository.db.WithContext(ctx))
 
 	var count int64
 	results := query.Model(*new(M)).Count(&count)
@@ -2,12 +2,16 @@ package std
 
 import (
 	"context"
+	"errors"
+	"strings"
 	"testing"
 
 	"github.com/javiorfo/gormen"
+	"github.com/javiorfo/gormen/internal/testutils"
 	"github.com/javiorfo/gormen/pagination"
 	"github.com/javiorfo/gormen/pagination/sort"
 	"github.com/javiorfo/gormen/where"
+	"github.com/javiorfo/nilo"
 )
 
 func TestRead(t *testing.T) {
@@ -65,6 +69,242 @@ func TestRead(t *testing.T) {
 		}
 	})
 
+	t.Run("Std FindAllBy comparison operators", func(t *testing.T) {
+		tests := []struct {
+			name     string
+			where    gormen.Where
+			expected int
+		}{
+			{"NotEqual", gormen.NewWhere(where.NotEqual("password", "123")).Build(), 1},
+			{"GreaterThan and LessOrEqual", gormen.NewWhere(where.GreaterThan("id", 1)).And(where.LessOrEqual("id", 3)).Build(), 2},
+			{"GreaterOrEqual", gormen.NewWhere(where.GreaterOrEqual("id", 3)).Build(), 1},
+			{"LessThan", gormen.NewWhere(where.LessThan("id", 2)).Build(), 1},
+			{"Between", gormen.NewWhere(where.Between("id", 1, 2)).Build(), 2},
+			{"NotIn", gormen.NewWhere(where.NotIn("username", "jdoe,batch1")).Build(), 1},
+			{"NotLike", gormen.NewWhere(where.NotLike("username", "batch%")).Build(), 1},
+			{"And then Or with slice", gormen.NewWhere(where.Equal("password", "123")).And(where.Equal("id", 2)).Or(where.In("id", []int{1})).Build(), 2},
+		}
+
+		for _, tt := range tests {
+			users, err := repo.FindAllBy(ctx, tt.where)
+			if err != nil {
+				t.Fatalf("executing find all by %s %v\n", tt.name, err)
+			}
+
+			if len(users) != tt.expected {
+				t.Fatalf("%s len must be %d, got %d\n", tt.name, tt.expected, len(users))
+			}
+		}
+	})
+
+	t.Run("Std FindAllBy null-aware operators", func(t *testing.T) {
+		var nilPassword *string
+
+		tests := []struct {
+			name     string
+			where    gormen.Where
+			expected int
+		}{
+			{"IsNull", gormen.NewWhere(where.IsNull("username")).Build(), 0},
+			{"IsNotNull", gormen.NewWhere(where.IsNotNull("username")).Build(), 3},
+			{"Equal nil", gormen.NewWhere(where.Equal("username", nil)).Build(), 0},
+			{"Equal empty option", gormen.NewWhere(where.Equal("username", nilo.Nil[string]())).Build(), 0},
+			{"Equal option with value", gormen.NewWhere(where.Equal("username", nilo.Value("jdoe"))).Build(), 1},
+			{"NotEqual nil pointer", gormen.NewWhere(where.NotEqual("password", nilPassword)).Build(), 3},
+		}
+
+		for _, tt := range tests {
+			users, err := repo.FindAllBy(ctx, tt.where)
+			if err != nil {
+				t.Fatalf("executing find all by %s %v\n", tt.name, err)
+			}
+
+			if len(users) != tt.expected {
+				t.Fatalf("%s len must be %d, got %d\n", tt.name, tt.expected, len(users))
+			}
+		}
+	})
+
+	t.Run("Std FindAllBy nested groups", func(t *testing.T) {
+		tests := []struct {
+			name     string
+			where    gormen.Where
+			expected int
+		}{
+			{"AnyOf with nested AllOf", gormen.NewWhere(where.AnyOf(
+				where.Equal("username", "jdoe"),
+				where.AllOf(where.Like("username", "batch%"), where.Equal("id", 2)),
+			)).Build(), 2},
+			{"And with AnyOf", gormen.NewWhere(where.Equal("password", "123")).And(where.AnyOf(where.Equal("id", 1), where.Equal("id", 2))).Build(), 1},
+			{"Not AnyOf", gormen.NewWhere(where.Not(where.AnyOf(where.Equal("username", "jdoe"), where.Equal("username", "batch1")))).Build(), 1},
+			{"Empty AllOf", gormen.NewWhere(where.AllOf()).Build(), 3},
+			{"Empty AnyOf", gormen.NewWhere(where.AnyOf()).Build(), 0},
+		}
+
+		for _, tt := range tests {
+			users, err := repo.FindAllBy(ctx, tt.where)
+			if err != nil {
+				t.Fatalf("executing find all by %s %v\n", tt.name, err)
+			}
+
+			if len(users) != tt.expected {
+				t.Fatalf("%s len must be %d, got %d\n", tt.name, tt.expected, len(users))
+			}
+		}
+	})
+
+	t.Run("Std FindAllBy escaped patterns", func(t *testing.T) {
+		tests := []struct {
+			name     string
+			where    gormen.Where
+			expected int
+		}{
+			{"Contains", gormen.NewWhere(where.Contains("username", "atch")).Build(), 2},
+			{"StartsWith", gormen.NewWhere(where.StartsWith("username", "jd")).Build(), 1},
+			{"EndsWith", gormen.NewWhere(where.EndsWith("username", "1")).Build(), 1},
+			{"Contains wildcard", gormen.NewWhere(where.Contains("username", "%")).Build(), 0},
+			{"StartsWith single char wildcard", gormen.NewWhere(where.StartsWith("username", "_doe")).Build(), 0},
+			{"ILike", gormen.NewWhere(where.ILike("username", "JD%")).Build(), 1},
+			{"IContains", gormen.NewWhere(where.IContains("username", "BATCH")).Build(), 2},
+			{"IStartsWith", gormen.NewWhere(where.IStartsWith("username", "JDO")).Build(), 1},
+			{"IEndsWith", gormen.NewWhere(where.IEndsWith("username", "CH2")).Build(), 1},
+		}
+
+		for _, tt := range tests {
+			users, err := repo.FindAllBy(ctx, tt.where)
+			if err != nil {
+				t.Fatalf("executing find all by %s %v\n", tt.name, err)
+			}
+
+			if len(users) != tt.expected {
+				t.Fatalf("%s len must be %d, got %d\n", tt.name, tt.expected, len(users))
+			}
+		}
+	})
+
+	t.Run("Std FindAllBy joined columns and aliases", func(t *testing.T) {
+		tests := []struct {
+			name     string
+			where    gormen.Where
+			expected int
+		}{
+			{"Raw join alias", gormen.NewWhere(where.Equal("p.email", "b1@mail.com")).
+				WithJoin("inner join persons p on p.id = users.person_id").Build(), 1},
+			{"Association join", gormen.NewWhere(where.Like("Person.name", "Batch%")).
+				WithJoin("Person").Build(), 2},
+		}
+
+		for _, tt := range tests {
+			users, err := repo.FindAllBy(ctx, tt.where)
+			if err != nil {
+				t.Fatalf("executing find all by %s %v\n", tt.name, err)
+			}
+
+			if len(users) != tt.expected {
+				t.Fatalf("%s len must be %d, got %d\n", tt.name, tt.expected, len(users))
+			}
+		}
+	})
+
+	t.Run("Std FindAllBy subqueries", func(t *testing.T) {
+		batches := gormen.NewWhere(where.StartsWith("name", "Batch")).Build()
+
+		tests := []struct {
+			name     string
+			where    gormen.Where
+			expected int
+		}{
+			{"Exists", gormen.NewWhere(where.Exists[testutils.PersonDB](batches, where.Correlate("id", "person_id"))).Build(), 2},
+			{"NotExists", gormen.NewWhere(where.NotExists[testutils.PersonDB](batches, where.Correlate("id", "users.person_id"))).Build(), 1},
+			{"InSubquery", gormen.NewWhere(where.InSubquery[testutils.PersonDB]("person_id", "id",
+				gormen.NewWhere(where.Equal("email", "jdoe@mail.com")).Build())).
+				And(where.Equal("password", "1234")).Build(), 1},
+		}
+
+		for _, tt := range tests {
+			users, err := repo.FindAllBy(ctx, tt.where)
+			if err != nil {
+				t.Fatalf("executing find all by %s %v\n", tt.name, err)
+			}
+
+			if len(users) != tt.expected {
+				t.Fatalf("%s len must be %d, got %d\n", tt.name, tt.expected, len(users))
+			}
+		}
+	})
+
+	t.Run("Std FindAllBy raw and column comparisons", func(t *testing.T) {
+		tests := []struct {
+			name     string
+			where    gormen.Where
+			expected int
+		}{
+			{"Raw", gormen.NewWhere(where.Raw("coalesce(username, password) = @name", where.NamedArgs{"name": "jdoe"})).Build(), 1},
+			{"EqualColumn", gormen.NewWhere(where.EqualColumn("id", "person_id")).Build(), 3},
+			{"GreaterThanColumn", gormen.NewWhere(where.GreaterThanColumn("id", "person_id")).Build(), 0},
+			{"LessOrEqualColumn joined", gormen.NewWhere(where.LessOrEqualColumn("id", "persons.id")).
+				WithJoin("inner join persons on users.person_id = persons.id").Build(), 3},
+		}
+
+		for _, tt := range tests {
+			users, err := repo.FindAllBy(ctx, tt.where)
+			if err != nil {
+				t.Fatalf("executing find all by %s %v\n", tt.name, err)
+			}
+
+			if len(users) != tt.expected {
+				t.Fatalf("%s len must be %d, got %d\n", tt.name, tt.expected, len(users))
+			}
+		}
+	})
+
+	t.Run("Std FindAllBy invalid columns", func(t *testing.T) {
+		tests := []struct {
+			name  string
+			where gormen.Where
+		}{
+			{"Unknown column", gormen.NewWhere(where.Equal("unknown", 1)).Build()},
+			{"Injection attempt", gormen.NewWhere(where.Equal("username = '' or 1 = 1 --", 1)).Build()},
+			{"Column of joined table", gormen.NewWhere(where.Equal("persons.username", "jdoe")).
+				WithJoin("inner join persons on users.person_id = persons.id").Build()},
+			{"Table not joined", gormen.NewWhere(where.Equal("persons.name", "jdoe")).Build()},
+			{"Subquery column", gormen.NewWhere(where.Exists[testutils.PersonDB](gormen.NewWhere(where.Equal("username", "jdoe")).Build())).Build()},
+			{"Subquery outer column", gormen.NewWhere(where.Exists[testutils.PersonDB](gormen.Where{}, where.Correlate("id", "email"))).Build()},
+		}
+
+		for _, tt := range tests {
+			_, err := repo.FindAllBy(ctx, tt.where)
+
+			var columnErr *where.ColumnError
+			if !errors.As(err, &columnErr) {
+				t.Fatalf("%s must return a column error, got %v\n", tt.name, err)
+			}
+		}
+	})
+
+	t.Run("Std FindAllBy and FindAllOrdered typed fields", func(t *testing.T) {
+		username := where.FieldOf(func(u *testutils.UserDB) *string { return &u.Username })
+		password := where.FieldOf(func(u *testutils.UserDB) *string { return &u.Password })
+
+		users, err := repo.FindAllBy(ctx, gormen.NewWhere(password.Eq("123")).And(username.In("batch1", "jdoe")).Build())
+		if err != nil {
+			t.Fatalf("executing find all by %v\n", err)
+		}
+
+		if len(users) != 1 || users[0].Username != "batch1" {
+			t.Fatalf("expected only batch1, got %v\n", users)
+		}
+
+		users, err = repo.FindAllOrdered(ctx, []sort.Order{username.Desc()})
+		if err != nil {
+			t.Fatalf("executing find all ordered %v\n", err)
+		}
+
+		if len(users) != 3 || users[0].Username != "jdoe" {
+			t.Fatalf("expected jdoe first, got %v\n", users)
+		}
+	})
+
 	t.Run("Std Count", func(t *testing.T) {
 		count, err := repo.Count(ctx)
 
@@ -153,4 +393,80 @@ func TestRead(t *testing.T) {
 			t.Fatalf("sorting elements. Got %s\n", page.Elements[0].Username)
 		}
 	})
+
+	t.Run("Std Explain", func(t *testing.T) {
+		type UserFilter struct {
+			Ids string `filter:"persons.id in (?);join:inner join persons on users.person_id = persons.id"`
+		}
+
+		pageRequest, err := pagination.PageRequestFrom(2, 5,
+			pagination.WithSortOrder("username", sort.Descending),
+			pagination.WithFilter(UserFilter{"1,2"}),
+		)
+		if err != nil {
+			t.Fatalf("creating page request %v\n", err)
+		}
+
+		explanation, err := repo.Explain(ctx, pageRequest, gormen.NewWhere(where.Like("username", "batch%")).Build())
+		if err != nil {
+			t.Fatalf("executing explain %v\n", err)
+		}
+
+		count := "SELECT count(*) FROM `users` inner join persons on users.person_id = persons.id " +
+			"WHERE `users`.`username` like ? AND persons.id in (?,?)"
+		if explanation.Count.SQL != count {
+			t.Fatalf("count statement must be %q, got %q\n", count, explanation.Count.SQL)
+		}
+
+		page := "SELECT `users`.`id`,`users`.`username`,`users`.`password`,`users`.`person_id` FROM `users` " +
+			"inner join persons on users.person_id = persons.id " +
+			"WHERE `users`.`username` like ? AND persons.id in (?,?) ORDER BY username desc LIMIT 5 OFFSET 5"
+		if explanation.Page.SQL != page {
+			t.Fatalf("page statement must be %q, got %q\n", page, explanation.Page.SQL)
+		}
+
+		if len(explanation.Page.Vars) != 3 || explanation.Page.Vars[0] != "batch%" {
+			t.Fatalf("unexpected page vars %v\n", explanation.Page.Vars)
+		}
+
+		_, err = repo.Explain(ctx, pageRequest, gormen.NewWhere(where.Equal("unknown", 1)).Build())
+		var columnErr *where.ColumnError
+		if !errors.As(err, &columnErr) {
+			t.Fatalf("explain must return a column error, got %v\n", err)
+		}
+	})
+
+	t.Run("Std Explain with association joins", func(t *testing.T) {
+		type UserFilter struct {
+			Email string `filter:"column:Person.email;op:contains;join:Person"`
+			Name  string `filter:"column:Person.name;op:startswith;join:Person"`
+		}
+
+		pageRequest, err := pagination.PageRequestFrom(1, 5, pagination.WithFilter(UserFilter{"mail", "J"}))
+		if err != nil {
+			t.Fatalf("creating page request %v\n", err)
+		}
+
+		w := gormen.NewWhere(where.Like("username", "%")).WithJoin("Person").Build()
+		explanation, err := repo.Explain(ctx, pageRequest, w)
+		if err != nil {
+			t.Fatalf("executing explain %v\n", err)
+		}
+
+		join := "LEFT JOIN `persons` `Person` ON `users`.`person_id` = `Person`.`id`"
+		for _, statement := range []string{explanation.Count.SQL, explanation.Page.SQL} {
+			if strings.Count(statement, "JOIN") != 1 || !strings.Contains(statement, join) {
+				t.Fatalf("statement must join Person once, got %q\n", statement)
+			}
+		}
+
+		page, err := repo.FindAllPaginatedBy(ctx, pageRequest, w)
+		if err != nil {
+			t.Fatalf("executing find all paginated %v\n", err)
+		}
+
+		if page.Total != 1 || len(page.Elements) != 1 || page.Elements[0].Username != "jdoe" {
+			t.Fatalf("page must only have jdoe, got %d %+v\n", page.Total, page.Elements)
+		}
+	})
 }
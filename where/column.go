@@ -0,0 +1,132 @@
+package where
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+)
+
+// ColumnError is returned when a condition references a column that is not a valid identifier
+// or does not belong to the entity schema or any of its joined tables.
+// It is reported before any SQL runs.
+type ColumnError struct {
+	// Column name as given to the condition
+	Column ColumnName
+}
+
+// Error satisfies the error interface.
+func (e *ColumnError) Error() string {
+	return fmt.Sprintf("'%s' is not a valid column", e.Column)
+}
+
+// identifier matches a plain SQL identifier, optionally qualified by a table name or alias.
+var identifier = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*(\.[A-Za-z_][A-Za-z0-9_]*)?$`)
+
+// joinedTable matches the table and optional alias of each join in a raw join clause.
+var joinedTable = regexp.MustCompile("(?i)\\bjoin\\s+[`\"]?(\\w+)[`\"]?(?:\\s+(?:as\\s+)?[`\"]?(\\w+)[`\"]?)?")
+
+// column is a clause expression that writes the resolved and quoted column name.
+type column ColumnName
+
+// Build writes the quoted column name, or reports a ColumnError if it is not valid.
+func (c column) Build(builder clause.Builder) {
+	resolved, err := resolveColumn(builder, ColumnName(c))
+	if err != nil {
+		_ = builder.AddError(err)
+		return
+	}
+	builder.WriteQuoted(resolved)
+}
+
+// resolveColumn validates the column name against the schema of the statement being built,
+// including its joined tables and their aliases, and returns it as a Gorm clause column
+// so it gets quoted for the dialect.
+// Names are only checked as identifiers when there is no schema to check them against.
+func resolveColumn(builder clause.Builder, name ColumnName) (clause.Column, error) {
+	if !identifier.MatchString(name) {
+		return clause.Column{}, &ColumnError{name}
+	}
+
+	table, columnName, qualified := strings.Cut(name, ".")
+	if !qualified {
+		table, columnName = clause.CurrentTable, name
+	}
+
+	stmt, ok := builder.(*gorm.Statement)
+	if !ok || stmt.Schema == nil {
+		return clause.Column{Table: table, Name: columnName}, nil
+	}
+
+	s := stmt.Schema
+	if qualified && table != stmt.Table && table != stmt.Schema.Table {
+		if s = joinedSchema(stmt, table); s == nil {
+			return clause.Column{}, &ColumnError{name}
+		}
+	}
+
+	field := s.LookUpField(columnName)
+	if field == nil || field.DBName == "" {
+		return clause.Column{}, &ColumnError{name}
+	}
+
+	return clause.Column{Table: table, Name: field.DBName}, nil
+}
+
+// joinedSchema returns the schema of the table joined under the given name or alias, or nil if none is.
+// Association joins are looked up by their relationship name; raw joins by the table they name,
+// which must be related to the statement schema.
+func joinedSchema(stmt *gorm.Statement, name string) *schema.Schema {
+	for _, join := range stmt.Joins {
+		if rel, ok := stmt.Schema.Relationships.Relations[join.Name]; ok {
+			alias := join.Name
+			if join.Alias != "" {
+				alias = join.Alias
+			}
+			if alias == name {
+				return rel.FieldSchema
+			}
+			continue
+		}
+
+		for _, match := range joinedTable.FindAllStringSubmatch(join.Name, -1) {
+			table, alias := match[1], match[2]
+			if strings.EqualFold(alias, "on") || strings.EqualFold(alias, "using") {
+				alias = ""
+			}
+
+			if table == name || alias == name {
+				return relatedSchema(stmt.Schema, table, map[*schema.Schema]bool{})
+			}
+		}
+	}
+
+	return nil
+}
+
+// relatedSchema searches the relationships of the schema, recursively, for the schema of the given table.
+func relatedSchema(s *schema.Schema, table string, visited map[*schema.Schema]bool) *schema.Schema {
+	if s.Table == table {
+		return s
+	}
+
+	if visited[s] {
+		return nil
+	}
+	visited[s] = true
+
+	for _, rel := range s.Relationships.Relations {
+		if rel.JoinTable != nil && rel.JoinTable.Table == table {
+			return rel.JoinTable
+		}
+
+		if found := relatedSchema(rel.FieldSchema, table, visited); found != nil {
+			return found
+		}
+	}
+
+	return nil
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	"github.com/javiorfo/gormen/internal/utils"
+	"gorm.io/gorm/clause"
 )
 
 // ColumnName represents the name of a database column.
@@ -13,9 +14,24 @@ type ColumnName = string
 type Value = any
 
 // Condition defines an interface for query conditions,
-// requiring a Get method that returns a query string and value.
+// requiring a Build method that writes the query snippet and its values
+// into a Gorm clause builder. Every Condition is a Gorm clause.Expression.
 type Condition interface {
-	Get() (string, any)
+	Build(clause.Builder)
+}
+
+// build resolves the column name and writes the query snippet, formatted with the quoted column,
+// and its bound values into the clause builder.
+// An invalid column is reported as a ColumnError instead of being written.
+func build(builder clause.Builder, name ColumnName, format string, values ...any) {
+	resolved, err := resolveColumn(builder, name)
+	if err != nil {
+		_ = builder.AddError(err)
+		return
+	}
+
+	vars := append([]any{resolved}, values...)
+	clause.Expr{SQL: fmt.Sprintf(format, "?"), Vars: vars}.Build(builder)
 }
 
 // like represents a SQL LIKE condition for pattern matching.
@@ -29,10 +45,10 @@ func Like(name ColumnName, value Value) like {
 	return like{name, value}
 }
 
-// Get returns the SQL LIKE query snippet and its corresponding value.
+// Build writes the SQL LIKE query snippet and its corresponding value.
 // Satisfies Condition interface
-func (l like) Get() (string, any) {
-	return fmt.Sprintf("%s like ?", l.name), l.value
+func (l like) Build(builder clause.Builder) {
+	build(builder, l.name, "%s like ?", l.value)
 }
 
 // in represents a SQL IN condition to match a column against multiple values.
@@ -46,15 +62,15 @@ func In(name ColumnName, value Value) in {
 	return in{name, value}
 }
 
-// Get returns the SQL IN query snippet and the processed list of values.
+// Build writes the SQL IN query snippet and the processed list of values.
 // Satisfies Condition interface
-func (i in) Get() (string, any) {
+func (i in) Build(builder clause.Builder) {
 	v := i.value
 	utils.GetValueAsCommaSeparated(i.value).Consume(func(s []string) {
 		v = s
 	})
 
-	return fmt.Sprintf("%s in (?)", i.name), v
+	build(builder, i.name, "%s in (?)", v)
 }
 
 // equal represents a SQL equality condition.
@@ -68,8 +84,194 @@ func Equal(name ColumnName, value Value) equal {
 	return equal{name, value}
 }
 
-// Get returns the SQL equality query snippet and its corresponding value.
+// Build writes the SQL equality query snippet and its corresponding value.
+// A nil value, nil pointer or empty nilo.Option renders as IS NULL.
+// Satisfies Condition interface
+func (e equal) Build(builder clause.Builder) {
+	value := utils.GetValueAsNullable(e.value)
+	if value.IsNil() {
+		IsNull(e.name).Build(builder)
+		return
+	}
+	build(builder, e.name, "%s = ?", value.AsValue())
+}
+
+// notEqual represents a SQL inequality condition.
+type notEqual struct {
+	name  ColumnName
+	value Value
+}
+
+// NotEqual constructs an inequality condition for the given column name and value.
+func NotEqual(name ColumnName, value Value) notEqual {
+	return notEqual{name, value}
+}
+
+// Build writes the SQL inequality query snippet and its corresponding value.
+// A nil value, nil pointer or empty nilo.Option renders as IS NOT NULL.
+// Satisfies Condition interface
+func (n notEqual) Build(builder clause.Builder) {
+	value := utils.GetValueAsNullable(n.value)
+	if value.IsNil() {
+		IsNotNull(n.name).Build(builder)
+		return
+	}
+	build(builder, n.name, "%s <> ?", value.AsValue())
+}
+
+// greaterThan represents a SQL greater than condition.
+type greaterThan struct {
+	name  ColumnName
+	value Value
+}
+
+// GreaterThan constructs a greater than condition for the given column name and value.
+func GreaterThan(name ColumnName, value Value) greaterThan {
+	return greaterThan{name, value}
+}
+
+// Build writes the SQL greater than query snippet and its corresponding value.
+// Satisfies Condition interface
+func (g greaterThan) Build(builder clause.Builder) {
+	build(builder, g.name, "%s > ?", g.value)
+}
+
+// greaterOrEqual represents a SQL greater than or equal condition.
+type greaterOrEqual struct {
+	name  ColumnName
+	value Value
+}
+
+// GreaterOrEqual constructs a greater than or equal condition for the given column name and value.
+func GreaterOrEqual(name ColumnName, value Value) greaterOrEqual {
+	return greaterOrEqual{name, value}
+}
+
+// Build writes the SQL greater than or equal query snippet and its corresponding value.
+// Satisfies Condition interface
+func (g greaterOrEqual) Build(builder clause.Builder) {
+	build(builder, g.name, "%s >= ?", g.value)
+}
+
+// lessThan represents a SQL less than condition.
+type lessThan struct {
+	name  ColumnName
+	value Value
+}
+
+// LessThan constructs a less than condition for the given column name and value.
+func LessThan(name ColumnName, value Value) lessThan {
+	return lessThan{name, value}
+}
+
+// Build writes the SQL less than query snippet and its corresponding value.
+// Satisfies Condition interface
+func (l lessThan) Build(builder clause.Builder) {
+	build(builder, l.name, "%s < ?", l.value)
+}
+
+// lessOrEqual represents a SQL less than or equal condition.
+type lessOrEqual struct {
+	name  ColumnName
+	value Value
+}
+
+// LessOrEqual constructs a less than or equal condition for the given column name and value.
+func LessOrEqual(name ColumnName, value Value) lessOrEqual {
+	return lessOrEqual{name, value}
+}
+
+// Build writes the SQL less than or equal query snippet and its corresponding value.
+// Satisfies Condition interface
+func (l lessOrEqual) Build(builder clause.Builder) {
+	build(builder, l.name, "%s <= ?", l.value)
+}
+
+// between represents a SQL BETWEEN condition with inclusive bounds.
+type between struct {
+	name ColumnName
+	from Value
+	to   Value
+}
+
+// Between constructs a BETWEEN condition for the given column name and inclusive bounds.
+func Between(name ColumnName, from, to Value) between {
+	return between{name, from, to}
+}
+
+// Build writes the SQL BETWEEN query snippet and its bounds.
+// Satisfies Condition interface
+func (b between) Build(builder clause.Builder) {
+	build(builder, b.name, "%s between ? and ?", b.from, b.to)
+}
+
+// notIn represents a SQL NOT IN condition to exclude multiple values.
+type notIn struct {
+	name  ColumnName
+	value Value
+}
+
+// NotIn constructs a NOT IN condition for the given column name and values.
+func NotIn(name ColumnName, value Value) notIn {
+	return notIn{name, value}
+}
+
+// Build writes the SQL NOT IN query snippet and the processed list of values.
+// Satisfies Condition interface
+func (n notIn) Build(builder clause.Builder) {
+	v := n.value
+	utils.GetValueAsCommaSeparated(n.value).Consume(func(s []string) {
+		v = s
+	})
+
+	build(builder, n.name, "%s not in (?)", v)
+}
+
+// notLike represents a SQL NOT LIKE condition for pattern exclusion.
+type notLike struct {
+	name  ColumnName
+	value Value
+}
+
+// NotLike constructs a NOT LIKE condition for the given column name and value.
+func NotLike(name ColumnName, value Value) notLike {
+	return notLike{name, value}
+}
+
+// Build writes the SQL NOT LIKE query snippet and its corresponding value.
+// Satisfies Condition interface
+func (n notLike) Build(builder clause.Builder) {
+	build(builder, n.name, "%s not like ?", n.value)
+}
+
+// isNull represents a SQL IS NULL condition.
+type isNull struct {
+	name ColumnName
+}
+
+// IsNull constructs an IS NULL condition for the given column name.
+func IsNull(name ColumnName) isNull {
+	return isNull{name}
+}
+
+// Build writes the SQL IS NULL query snippet; it has no value to bind.
+// Satisfies Condition interface
+func (i isNull) Build(builder clause.Builder) {
+	build(builder, i.name, "%s is null")
+}
+
+// isNotNull represents a SQL IS NOT NULL condition.
+type isNotNull struct {
+	name ColumnName
+}
+
+// IsNotNull constructs an IS NOT NULL condition for the given column name.
+func IsNotNull(name ColumnName) isNotNull {
+	return isNotNull{name}
+}
+
+// Build writes the SQL IS NOT NULL query snippet; it has no value to bind.
 // Satisfies Condition interface
-func (e equal) Get() (string, any) {
-	return fmt.Sprintf("%s = ?", e.name), e.value
+func (i isNotNull) Build(builder clause.Builder) {
+	build(builder, i.name, "%s is not null")
 }
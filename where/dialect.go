@@ -0,0 +1,18 @@
+package where
+
+import (
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// postgres is the dialect name reported by the Gorm Postgres dialector.
+const postgres = "postgres"
+
+// dialect returns the name of the Gorm dialect of the statement being built,
+// or an empty string when the builder is not a Gorm statement.
+func dialect(builder clause.Builder) string {
+	if stmt, ok := builder.(*gorm.Statement); ok && stmt.Dialector != nil {
+		return stmt.Dialector.Name()
+	}
+	return ""
+}
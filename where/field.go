@@ -0,0 +1,134 @@
+package where
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/javiorfo/gormen/pagination/sort"
+	"gorm.io/gorm/schema"
+)
+
+// schemas caches the Gorm schemas parsed to resolve typed fields.
+var schemas = &sync.Map{}
+
+// Field references the column of a field of type T in entity E.
+// Its methods only accept values of type T, so type mismatches are caught at compile time.
+type Field[E, T any] struct {
+	name ColumnName
+}
+
+// FieldOf constructs a Field for the field of entity E returned by the selector, for example
+// where.FieldOf(func(u *UserDB) *string { return &u.Username }).
+// The column name is qualified with the table of E, as resolved by the default Gorm naming strategy
+// and the TableName method of E.
+// It panics if the selector does not return a field of E mapped to a column,
+// so it is meant to be used when declaring fields.
+func FieldOf[E, T any](selector func(*E) *T) Field[E, T] {
+	entity := new(E)
+
+	s, err := schema.Parse(entity, schemas, schema.NamingStrategy{})
+	if err != nil {
+		panic(fmt.Sprintf("parsing schema of %T: %v", entity, err))
+	}
+
+	offset := reflect.ValueOf(selector(entity)).Pointer() - reflect.ValueOf(entity).Pointer()
+	fieldType := reflect.TypeFor[T]()
+
+	for _, f := range s.Fields {
+		if f.DBName != "" && f.FieldType == fieldType && fieldOffset(s.ModelType, f.StructField.Index) == offset {
+			return Field[E, T]{s.Table + "." + f.DBName}
+		}
+	}
+
+	panic(fmt.Sprintf("selector does not return a field of %T mapped to a column", entity))
+}
+
+// fieldOffset returns the offset, from the start of the struct, of the field at the given index path.
+func fieldOffset(t reflect.Type, index []int) uintptr {
+	var offset uintptr
+	for _, i := range index {
+		f := t.Field(i)
+		offset += f.Offset
+		t = f.Type
+	}
+	return offset
+}
+
+// Name returns the column name, qualified with its table.
+func (f Field[E, T]) Name() ColumnName {
+	return f.name
+}
+
+// Qualified returns a copy of the Field qualified with another table name or alias,
+// for example the relationship name used by an association join.
+func (f Field[E, T]) Qualified(table string) Field[E, T] {
+	_, column, _ := strings.Cut(f.name, ".")
+	return Field[E, T]{table + "." + column}
+}
+
+// Eq constructs an equality condition on the field.
+func (f Field[E, T]) Eq(value T) Condition {
+	return Equal(f.name, value)
+}
+
+// NotEq constructs an inequality condition on the field.
+func (f Field[E, T]) NotEq(value T) Condition {
+	return NotEqual(f.name, value)
+}
+
+// Gt constructs a greater than condition on the field.
+func (f Field[E, T]) Gt(value T) Condition {
+	return GreaterThan(f.name, value)
+}
+
+// Gte constructs a greater than or equal condition on the field.
+func (f Field[E, T]) Gte(value T) Condition {
+	return GreaterOrEqual(f.name, value)
+}
+
+// Lt constructs a less than condition on the field.
+func (f Field[E, T]) Lt(value T) Condition {
+	return LessThan(f.name, value)
+}
+
+// Lte constructs a less than or equal condition on the field.
+func (f Field[E, T]) Lte(value T) Condition {
+	return LessOrEqual(f.name, value)
+}
+
+// Between constructs a BETWEEN condition on the field with inclusive bounds.
+func (f Field[E, T]) Between(from, to T) Condition {
+	return Between(f.name, from, to)
+}
+
+// In constructs an IN condition on the field.
+func (f Field[E, T]) In(values ...T) Condition {
+	return In(f.name, values)
+}
+
+// NotIn constructs a NOT IN condition on the field.
+func (f Field[E, T]) NotIn(values ...T) Condition {
+	return NotIn(f.name, values)
+}
+
+// IsNull constructs an IS NULL condition on the field.
+func (f Field[E, T]) IsNull() Condition {
+	return IsNull(f.name)
+}
+
+// IsNotNull constructs an IS NOT NULL condition on the field.
+func (f Field[E, T]) IsNotNull() Condition {
+	return IsNotNull(f.name)
+}
+
+// Asc returns an ascending sort.Order by the field.
+func (f Field[E, T]) Asc() sort.Order {
+	return sort.NewOrder(f.name, sort.Ascending)
+}
+
+// Desc returns a descending sort.Order by the field.
+func (f Field[E, T]) Desc() sort.Order {
+	return sort.NewOrder(f.name, sort.Descending)
+}
@@ -0,0 +1,57 @@
+package where
+
+import (
+	"testing"
+
+	"github.com/javiorfo/gormen/internal/testutils"
+	"github.com/javiorfo/gormen/pagination/sort"
+)
+
+func TestFieldOf(t *testing.T) {
+	username := FieldOf(func(u *testutils.UserDB) *string { return &u.Username })
+	if username.Name() != "users.username" {
+		t.Errorf("expected users.username, got %s", username.Name())
+	}
+
+	personID := FieldOf(func(u *testutils.UserDB) *uint { return &u.PersonID })
+	if personID.Name() != "users.person_id" {
+		t.Errorf("expected users.person_id, got %s", personID.Name())
+	}
+
+	email := FieldOf(func(p *testutils.PersonDB) *string { return &p.Email }).Qualified("Person")
+	if email.Name() != "Person.email" {
+		t.Errorf("expected Person.email, got %s", email.Name())
+	}
+
+	order := username.Desc()
+	if order.By() != "users.username" || order.Direction() != sort.Descending {
+		t.Errorf("unexpected order %s", order.Get())
+	}
+}
+
+func TestFieldOf_PanicsOnValueOutsideEntity(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("expected panic for a value outside the entity")
+		}
+	}()
+
+	FieldOf(func(u *testutils.UserDB) *string {
+		outside := u.Username
+		return &outside
+	})
+}
+
+func TestField_Build(t *testing.T) {
+	id := FieldOf(func(u *testutils.UserDB) *uint { return &u.ID })
+
+	stmt := testutils.SetupDryRunDB("sqlite").
+		Where(id.In(1, 2)).
+		Where(id.Between(1, 5)).
+		Find(&[]testutils.UserDB{}).Statement
+
+	expected := "SELECT * FROM `users` WHERE `users`.`id` in (?,?) AND `users`.`id` between ? and ?"
+	if sql := stmt.SQL.String(); sql != expected {
+		t.Fatalf("expected %q, got %q", expected, sql)
+	}
+}
@@ -0,0 +1,73 @@
+package where
+
+import "gorm.io/gorm/clause"
+
+// allOf represents a parenthesized group of conditions joined by AND.
+type allOf struct {
+	conditions []Condition
+}
+
+// AllOf constructs a group that matches when every condition matches.
+// An empty group always matches.
+func AllOf(conditions ...Condition) allOf {
+	return allOf{conditions}
+}
+
+// Build writes the conditions joined by AND inside parentheses.
+// Satisfies Condition interface
+func (a allOf) Build(builder clause.Builder) {
+	group(builder, a.conditions, clause.AndWithSpace, "1 = 1")
+}
+
+// anyOf represents a parenthesized group of conditions joined by OR.
+type anyOf struct {
+	conditions []Condition
+}
+
+// AnyOf constructs a group that matches when at least one condition matches.
+// An empty group never matches.
+func AnyOf(conditions ...Condition) anyOf {
+	return anyOf{conditions}
+}
+
+// Build writes the conditions joined by OR inside parentheses.
+// Satisfies Condition interface
+func (a anyOf) Build(builder clause.Builder) {
+	group(builder, a.conditions, clause.OrWithSpace, "1 <> 1")
+}
+
+// not represents the negation of a condition.
+type not struct {
+	condition Condition
+}
+
+// Not constructs a condition that matches when the given condition does not.
+func Not(condition Condition) not {
+	return not{condition}
+}
+
+// Build writes NOT followed by the parenthesized condition.
+// Satisfies Condition interface
+func (n not) Build(builder clause.Builder) {
+	builder.WriteString("NOT (")
+	n.condition.Build(builder)
+	builder.WriteByte(')')
+}
+
+// group writes the conditions separated by the given logical operator inside parentheses,
+// or the neutral snippet when there are no conditions.
+func group(builder clause.Builder, conditions []Condition, operator, neutral string) {
+	if len(conditions) == 0 {
+		builder.WriteString(neutral)
+		return
+	}
+
+	builder.WriteByte('(')
+	for i, c := range conditions {
+		if i > 0 {
+			builder.WriteString(operator)
+		}
+		c.Build(builder)
+	}
+	builder.WriteByte(')')
+}
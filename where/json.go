@@ -0,0 +1,340 @@
+package where
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/javiorfo/gormen/internal/utils"
+	"github.com/javiorfo/gormen/pagination/sort"
+	"gorm.io/gorm/clause"
+)
+
+// mysql is the dialect name reported by the Gorm MySQL dialector.
+const mysql = "mysql"
+
+// JSONPathError is returned when a JSON path is not made of object keys and array indexes.
+// It is reported before any SQL runs.
+type JSONPathError struct {
+	// Path as given to JSONPath
+	Path string
+}
+
+// Error satisfies the error interface.
+func (e *JSONPathError) Error() string {
+	return fmt.Sprintf("'%s' is not a valid JSON path", e.Path)
+}
+
+// pathSegment matches the next object key (.key) or array index ([0]) of a JSON path.
+var pathSegment = regexp.MustCompile(`^(?:\.([A-Za-z_][A-Za-z0-9_]*)|\[([0-9]+)\])`)
+
+// segment is an object key or, when the key is empty, an array index of a JSON path.
+type segment struct {
+	key   string
+	index int
+}
+
+// parsePath splits a JSON path such as "$.size.width", "size.width" or "tags[0]" into its segments.
+// Keys are restricted to identifiers so the path can be written in the query as a literal.
+func parsePath(path string) ([]segment, error) {
+	rest := strings.TrimPrefix(path, "$")
+	if rest != "" && rest[0] != '.' && rest[0] != '[' {
+		rest = "." + rest
+	}
+
+	var segments []segment
+	for rest != "" {
+		match := pathSegment.FindStringSubmatch(rest)
+		if match == nil {
+			return nil, &JSONPathError{path}
+		}
+
+		if match[1] != "" {
+			segments = append(segments, segment{key: match[1]})
+		} else {
+			index, err := strconv.Atoi(match[2])
+			if err != nil {
+				return nil, &JSONPathError{path}
+			}
+			segments = append(segments, segment{index: index})
+		}
+		rest = rest[len(match[0]):]
+	}
+
+	if len(segments) == 0 {
+		return nil, &JSONPathError{path}
+	}
+
+	return segments, nil
+}
+
+// jsonPath references a value inside a JSON column.
+type jsonPath struct {
+	name ColumnName
+	path string
+}
+
+// JSONPath references the value at the given path of a JSON column,
+// e.g. where.JSONPath("attrs", "$.color") or where.JSONPath("attrs", "size.width").
+// Paths are made of object keys (identifiers) and array indexes, such as "$.tags[0]".
+// Used as an expression it extracts the value with json_extract on SQLite, ->> on Postgres
+// and JSON_EXTRACT on MySQL, so it can also be sorted by or compared in filter structs.
+func JSONPath(name ColumnName, path string) jsonPath {
+	return jsonPath{name, path}
+}
+
+// Build writes the SQL expression extracting the value at the path.
+// On Postgres the value is extracted as text.
+// Satisfies clause.Expression interface
+func (j jsonPath) Build(builder clause.Builder) {
+	j.write(builder, true)
+}
+
+// write resolves the column and writes the dialect specific extraction of the value at the path.
+// On Postgres, text selects ->> over -> for the last segment.
+func (j jsonPath) write(builder clause.Builder, text bool) {
+	segments, err := parsePath(j.path)
+	if err != nil {
+		_ = builder.AddError(err)
+		return
+	}
+
+	resolved, err := resolveColumn(builder, j.name)
+	if err != nil {
+		_ = builder.AddError(err)
+		return
+	}
+
+	switch dialect(builder) {
+	case postgres:
+		builder.WriteQuoted(resolved)
+		for i, s := range segments {
+			if i == len(segments)-1 && text {
+				builder.WriteString("->>")
+			} else {
+				builder.WriteString("->")
+			}
+
+			if s.key != "" {
+				builder.WriteString("'" + s.key + "'")
+			} else {
+				builder.WriteString(strconv.Itoa(s.index))
+			}
+		}
+	case mysql:
+		j.call(builder, "JSON_EXTRACT", resolved, segments)
+	default:
+		j.call(builder, "json_extract", resolved, segments)
+	}
+}
+
+// call writes a SQL function call over the quoted column and the path as a literal.
+func (j jsonPath) call(builder clause.Builder, function string, resolved clause.Column, segments []segment, args ...string) {
+	builder.WriteString(function + "(")
+	builder.WriteQuoted(resolved)
+	for _, arg := range args {
+		builder.WriteString(", " + arg)
+	}
+	builder.WriteString(", '" + literal(segments) + "')")
+}
+
+// literal returns the JSON path of the segments in the $.key[0] form used by SQLite and MySQL.
+func literal(segments []segment) string {
+	var path strings.Builder
+	path.WriteString("$")
+	for _, s := range segments {
+		if s.key != "" {
+			path.WriteString("." + s.key)
+		} else {
+			path.WriteString("[" + strconv.Itoa(s.index) + "]")
+		}
+	}
+	return path.String()
+}
+
+// Equal constructs an equality condition on the value at the path.
+// A nil value, nil pointer or empty nilo.Option matches missing and JSON null values.
+func (j jsonPath) Equal(value Value) jsonCondition {
+	return jsonCondition{j, "= ?", value}
+}
+
+// In constructs an IN condition on the value at the path.
+func (j jsonPath) In(value Value) jsonCondition {
+	return jsonCondition{j, "in (?)", value}
+}
+
+// Exists constructs a condition matching when the path is present in the JSON column,
+// even if its value is JSON null.
+func (j jsonPath) Exists() jsonCondition {
+	return jsonCondition{j, "exists", nil}
+}
+
+// Contains constructs a condition matching when the JSON array at the path contains the value.
+func (j jsonPath) Contains(value Value) jsonCondition {
+	return jsonCondition{j, "contains", value}
+}
+
+// Asc returns an ascending sort.Order by the value at the path.
+func (j jsonPath) Asc() sort.Order {
+	return sort.NewExpressionOrder(j.name+"->"+j.path, j, sort.Ascending)
+}
+
+// Desc returns a descending sort.Order by the value at the path.
+func (j jsonPath) Desc() sort.Order {
+	return sort.NewExpressionOrder(j.name+"->"+j.path, j, sort.Descending)
+}
+
+// jsonCondition represents a SQL condition on the value at the path of a JSON column.
+type jsonCondition struct {
+	path     jsonPath
+	operator string
+	value    Value
+}
+
+// Build writes the dialect specific SQL condition and its values.
+// On Postgres values are compared as jsonb, so they are bound JSON encoded and cast with ::jsonb,
+// as is the value at the path, which works on both json and jsonb columns.
+// Satisfies Condition interface
+func (c jsonCondition) Build(builder clause.Builder) {
+	switch c.operator {
+	case "exists":
+		c.exists(builder)
+	case "contains":
+		c.contains(builder)
+	default:
+		c.compare(builder)
+	}
+}
+
+// compare writes the comparison of the value at the path.
+func (c jsonCondition) compare(builder clause.Builder) {
+	value := utils.GetValueAsNullable(c.value)
+	if value.IsNil() {
+		clause.Expr{SQL: "? is null", Vars: []any{c.path}}.Build(builder)
+		return
+	}
+
+	v := value.AsValue()
+	if c.operator == "in (?)" {
+		utils.GetValueAsCommaSeparated(v).Consume(func(s []string) {
+			v = s
+		})
+	}
+
+	if dialect(builder) != postgres {
+		clause.Expr{SQL: "? " + c.operator, Vars: []any{c.path, v}}.Build(builder)
+		return
+	}
+
+	encoded, err := encodeJSON(v, c.operator == "in (?)")
+	if err != nil {
+		_ = builder.AddError(err)
+		return
+	}
+	c.jsonb(builder)
+
+	values, many := encoded.([]string)
+	if !many {
+		clause.Expr{SQL: " = ?::jsonb", Vars: []any{encoded}}.Build(builder)
+		return
+	}
+
+	placeholders := make([]string, len(values))
+	vars := make([]any, len(values))
+	for i, value := range values {
+		placeholders[i], vars[i] = "?::jsonb", value
+	}
+	clause.Expr{SQL: " in (" + strings.Join(placeholders, ",") + ")", Vars: vars}.Build(builder)
+}
+
+// jsonb writes the Postgres extraction of the value at the path cast to jsonb,
+// so it can be compared on json and jsonb columns alike.
+func (c jsonCondition) jsonb(builder clause.Builder) {
+	builder.WriteByte('(')
+	c.path.write(builder, false)
+	builder.WriteString(")::jsonb")
+}
+
+// exists writes the condition checking the path is present.
+func (c jsonCondition) exists(builder clause.Builder) {
+	switch dialect(builder) {
+	case postgres:
+		c.path.write(builder, false)
+		builder.WriteString(" is not null")
+	case mysql:
+		c.write(builder, "JSON_CONTAINS_PATH", "'one'")
+	default:
+		c.write(builder, "json_type")
+		builder.WriteString(" is not null")
+	}
+}
+
+// contains writes the condition checking the JSON array at the path contains the value.
+func (c jsonCondition) contains(builder clause.Builder) {
+	switch dialect(builder) {
+	case postgres, mysql:
+		encoded, err := json.Marshal(c.value)
+		if err != nil {
+			_ = builder.AddError(err)
+			return
+		}
+
+		if dialect(builder) == mysql {
+			builder.WriteString("JSON_CONTAINS(")
+			c.write(builder, "JSON_EXTRACT")
+			clause.Expr{SQL: ", ?)", Vars: []any{string(encoded)}}.Build(builder)
+			return
+		}
+
+		c.jsonb(builder)
+		clause.Expr{SQL: " @> ?::jsonb", Vars: []any{string(encoded)}}.Build(builder)
+	default:
+		builder.WriteString("exists (select 1 from ")
+		c.write(builder, "json_each")
+		clause.Expr{SQL: " where value = ?)", Vars: []any{c.value}}.Build(builder)
+	}
+}
+
+// write writes a SQL function call over the column and the path, reporting invalid ones.
+func (c jsonCondition) write(builder clause.Builder, function string, args ...string) {
+	segments, err := parsePath(c.path.path)
+	if err != nil {
+		_ = builder.AddError(err)
+		return
+	}
+
+	resolved, err := resolveColumn(builder, c.path.name)
+	if err != nil {
+		_ = builder.AddError(err)
+		return
+	}
+
+	c.path.call(builder, function, resolved, segments, args...)
+}
+
+// encodeJSON encodes the value, or each element of a slice when many is true, as JSON text.
+func encodeJSON(value any, many bool) (any, error) {
+	if !many {
+		encoded, err := json.Marshal(value)
+		return string(encoded), err
+	}
+
+	v := reflect.ValueOf(value)
+	if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+		return nil, errors.New("'in' values must be a slice")
+	}
+
+	encoded := make([]string, v.Len())
+	for i := range v.Len() {
+		e, err := json.Marshal(v.Index(i).Interface())
+		if err != nil {
+			return nil, err
+		}
+		encoded[i] = string(e)
+	}
+	return encoded, nil
+}
@@ -0,0 +1,100 @@
+package where
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/javiorfo/gormen/internal/testutils"
+	"github.com/javiorfo/gormen/pagination/sort"
+)
+
+type productDB struct {
+	ID    uint
+	Attrs string
+}
+
+func (productDB) TableName() string {
+	return "products"
+}
+
+func TestJSONPath_Build(t *testing.T) {
+	color := JSONPath("attrs", "$.color")
+	tags := JSONPath("attrs", "tags")
+
+	tests := []struct {
+		name      string
+		dialect   string
+		condition Condition
+		sql       string
+		vars      []any
+	}{
+		{"Equal on sqlite", "sqlite", color.Equal("red"), "json_extract(`products`.`attrs`, '$.color') = ?", []any{"red"}},
+		{"Equal on mysql", "mysql", color.Equal("red"), "JSON_EXTRACT(`products`.`attrs`, '$.color') = ?", []any{"red"}},
+		{"Equal on postgres", "postgres", color.Equal("red"), "(`products`.`attrs`->'color')::jsonb = ?::jsonb", []any{`"red"`}},
+		{"Equal nil", "postgres", color.Equal(nil), "`products`.`attrs`->>'color' is null", nil},
+		{"Nested path", "postgres", JSONPath("attrs", "size.dims[1]").Equal(3), "(`products`.`attrs`->'size'->'dims'->1)::jsonb = ?::jsonb", []any{"3"}},
+		{"In on sqlite", "sqlite", color.In([]string{"red", "blue"}), "json_extract(`products`.`attrs`, '$.color') in (?,?)", []any{"red", "blue"}},
+		{"In on postgres", "postgres", color.In("red,blue"), "(`products`.`attrs`->'color')::jsonb in (?::jsonb,?::jsonb)", []any{`"red"`, `"blue"`}},
+		{"Exists on sqlite", "sqlite", color.Exists(), "json_type(`products`.`attrs`, '$.color') is not null", nil},
+		{"Exists on mysql", "mysql", color.Exists(), "JSON_CONTAINS_PATH(`products`.`attrs`, 'one', '$.color')", nil},
+		{"Exists on postgres", "postgres", color.Exists(), "`products`.`attrs`->'color' is not null", nil},
+		{"Contains on sqlite", "sqlite", tags.Contains("new"),
+			"exists (select 1 from json_each(`products`.`attrs`, '$.tags') where value = ?)", []any{"new"}},
+		{"Contains on mysql", "mysql", tags.Contains("new"), "JSON_CONTAINS(JSON_EXTRACT(`products`.`attrs`, '$.tags'), ?)", []any{`"new"`}},
+		{"Contains on postgres", "postgres", tags.Contains("new"), "(`products`.`attrs`->'tags')::jsonb @> ?::jsonb", []any{`"new"`}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stmt := testutils.SetupDryRunDB(tt.dialect).Where(tt.condition).Find(&[]productDB{}).Statement
+			if stmt.Error != nil {
+				t.Fatalf("unexpected error: %v", stmt.Error)
+			}
+
+			expected := "SELECT * FROM `products` WHERE " + tt.sql
+			if sql := stmt.SQL.String(); sql != expected {
+				t.Fatalf("expected %q, got %q", expected, sql)
+			}
+
+			if len(stmt.Vars) != len(tt.vars) {
+				t.Fatalf("expected vars %v, got %v", tt.vars, stmt.Vars)
+			}
+			for i := range tt.vars {
+				if stmt.Vars[i] != tt.vars[i] {
+					t.Errorf("expected var %d to be %v, got %v", i, tt.vars[i], stmt.Vars[i])
+				}
+			}
+		})
+	}
+}
+
+func TestJSONPath_Order(t *testing.T) {
+	orders := []sort.Order{JSONPath("attrs", "$.rank").Desc(), sort.Default()}
+	stmt := testutils.SetupDryRunDB("postgres").Order(sort.OrderBy(orders)).Find(&[]productDB{}).Statement
+
+	expected := "SELECT * FROM `products` ORDER BY `products`.`attrs`->>'rank' desc, id asc"
+	if sql := stmt.SQL.String(); sql != expected {
+		t.Errorf("expected %q, got %q", expected, sql)
+	}
+}
+
+func TestJSONPath_Invalid(t *testing.T) {
+	tests := []struct {
+		name      string
+		condition Condition
+		target    any
+	}{
+		{"Quote in key", JSONPath("attrs", "$.a'b").Equal(1), new(*JSONPathError)},
+		{"Empty path", JSONPath("attrs", "$").Exists(), new(*JSONPathError)},
+		{"Unknown column", JSONPath("meta", "$.a").Contains(1), new(*ColumnError)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := testutils.SetupDryRunDB("sqlite").Where(tt.condition).Find(&[]productDB{}).Error
+			if !errors.As(err, tt.target) {
+				t.Errorf("expected %T, got %v", tt.target, err)
+			}
+		})
+	}
+}
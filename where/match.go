@@ -0,0 +1,277 @@
+package where
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/javiorfo/gormen/pagination/sort"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// defaultLanguage is the Postgres text search configuration used when none is given;
+// it neither stems nor drops stop words.
+const defaultLanguage = "simple"
+
+// match represents a full-text search condition over one or more columns of the same table.
+type match struct {
+	columns  []ColumnName
+	query    string
+	language string
+}
+
+// Match constructs a full-text search condition matching records whose columns contain
+// every word of the query, in any order. The query is plain text: its words are searched literally.
+// It is backed by the FTS5 table created by CreateMatchIndex on SQLite, tsvector and tsquery on Postgres
+// and a FULLTEXT index on MySQL. An empty query matches every record.
+func Match(columns []ColumnName, query string) match {
+	return match{columns, query, defaultLanguage}
+}
+
+// Language returns a copy of the condition using the given Postgres text search configuration,
+// such as "english", which must be the same one given to CreateMatchIndex. Other dialects ignore it.
+func (m match) Language(language string) match {
+	m.language = language
+	return m
+}
+
+// Build writes the dialect specific full-text search condition and its query.
+// Satisfies Condition interface
+func (m match) Build(builder clause.Builder) {
+	words := strings.Fields(m.query)
+	if len(words) == 0 {
+		builder.WriteString("1 = 1")
+		return
+	}
+
+	switch dialect(builder) {
+	case postgres:
+		m.document(builder)
+		clause.Expr{SQL: " @@ ?", Vars: []any{m.tsquery()}}.Build(builder)
+	case mysql:
+		m.against(builder, strings.Join(words, " "))
+	default:
+		table, _, err := m.resolve(builder)
+		if err != nil {
+			_ = builder.AddError(err)
+			return
+		}
+
+		builder.WriteQuoted(clause.PrimaryColumn)
+		builder.WriteString(" in (select rowid from ")
+		builder.WriteQuoted(table + ftsSuffix)
+		clause.Expr{SQL: " where ? match ?)", Vars: []any{clause.Table{Name: table + ftsSuffix}, m.fts5Query(builder, words)}}.Build(builder)
+	}
+}
+
+// ByRelevance returns a sort.Order listing the best matches of the query first.
+// It ranks with bm25 on SQLite, ts_rank on Postgres and the MATCH score on MySQL.
+func (m match) ByRelevance() sort.Order {
+	return sort.NewExpressionOrder("relevance", relevance(m), sort.Descending)
+}
+
+// relevance is a clause expression that writes the relevance score of a match, higher being better.
+type relevance match
+
+// Build writes the dialect specific relevance score of the match.
+// Satisfies clause.Expression interface
+func (r relevance) Build(builder clause.Builder) {
+	m := match(r)
+	words := strings.Fields(m.query)
+	if len(words) == 0 {
+		builder.WriteString("0")
+		return
+	}
+
+	switch dialect(builder) {
+	case postgres:
+		builder.WriteString("ts_rank(")
+		m.document(builder)
+		clause.Expr{SQL: ", ?)", Vars: []any{m.tsquery()}}.Build(builder)
+	case mysql:
+		m.against(builder, strings.Join(words, " "))
+	default:
+		table, _, err := m.resolve(builder)
+		if err != nil {
+			_ = builder.AddError(err)
+			return
+		}
+
+		fts := clause.Table{Name: table + ftsSuffix}
+		clause.Expr{
+			SQL:  "(select -bm25(?) from ? where ? match ? and rowid = ?)",
+			Vars: []any{fts, fts, fts, m.fts5Query(builder, words), clause.Column{Table: table, Name: clause.PrimaryKey}},
+		}.Build(builder)
+	}
+}
+
+// ftsSuffix is appended to the table name to name its full-text index.
+const ftsSuffix = "_fts"
+
+// resolve validates the columns, which must belong to the same table, and returns that table
+// and the column names as written in it.
+func (m match) resolve(builder clause.Builder) (string, []string, error) {
+	if len(m.columns) == 0 {
+		return "", nil, errors.New("match must search at least one column")
+	}
+
+	var table string
+	names := make([]string, len(m.columns))
+	for i, c := range m.columns {
+		resolved, err := resolveColumn(builder, c)
+		if err != nil {
+			return "", nil, err
+		}
+
+		t := resolved.Table
+		if stmt, ok := builder.(*gorm.Statement); ok && t == clause.CurrentTable {
+			t = stmt.Table
+		}
+		if i > 0 && t != table {
+			return "", nil, fmt.Errorf("match columns must belong to the same table, got '%s' and '%s'", table, t)
+		}
+
+		table, names[i] = t, resolved.Name
+	}
+
+	return table, names, nil
+}
+
+// fts5Query returns the FTS5 query restricted to the columns with every word as a quoted string,
+// so FTS5 operators in user input are searched literally.
+func (m match) fts5Query(builder clause.Builder, words []string) string {
+	_, names, err := m.resolve(builder)
+	if err != nil {
+		_ = builder.AddError(err)
+		return ""
+	}
+
+	quoted := make([]string, len(words))
+	for i, w := range words {
+		quoted[i] = `"` + strings.ReplaceAll(w, `"`, `""`) + `"`
+	}
+
+	return "{" + strings.Join(names, " ") + "} : " + strings.Join(quoted, " ")
+}
+
+// tsquery returns the Postgres text search query of the words in the match language.
+func (m match) tsquery() clause.Expr {
+	return clause.Expr{SQL: "plainto_tsquery(?, ?)", Vars: []any{m.language, m.query}}
+}
+
+// document writes the Postgres tsvector of the columns, the same expression indexed by CreateMatchIndex.
+func (m match) document(builder clause.Builder) {
+	if !identifier.MatchString(m.language) {
+		_ = builder.AddError(fmt.Errorf("'%s' is not a valid text search configuration", m.language))
+		return
+	}
+
+	builder.WriteString("to_tsvector('" + m.language + "', ")
+	for i, c := range m.columns {
+		if i > 0 {
+			builder.WriteString(" || ' ' || ")
+		}
+		clause.Expr{SQL: "coalesce(?, '')", Vars: []any{column(c)}}.Build(builder)
+	}
+	builder.WriteString(")")
+}
+
+// against writes the MySQL MATCH ... AGAINST expression in natural language mode.
+func (m match) against(builder clause.Builder, query string) {
+	builder.WriteString("MATCH(")
+	for i, c := range m.columns {
+		if i > 0 {
+			builder.WriteString(", ")
+		}
+		column(c).Build(builder)
+	}
+	clause.Expr{SQL: ") AGAINST (? IN NATURAL LANGUAGE MODE)", Vars: []any{query}}.Build(builder)
+}
+
+// CreateMatchIndex creates, if it does not exist, the full-text index Match searches on the columns of the model:
+// an FTS5 table named after the model table with the _fts suffix on SQLite, a GIN index on the tsvector
+// of the columns in the given language on Postgres and a FULLTEXT index on MySQL.
+// On SQLite the FTS5 table is filled with the existing records and triggers keep it in sync
+// on every insert, update and delete, including those of CudRepository.
+func CreateMatchIndex(db *gorm.DB, model any, language string, columns ...ColumnName) error {
+	stmt := &gorm.Statement{DB: db.Session(&gorm.Session{NewDB: true})}
+	if err := stmt.Parse(model); err != nil {
+		return err
+	}
+
+	if len(columns) == 0 {
+		return errors.New("match index must include at least one column")
+	}
+
+	if language == "" {
+		language = defaultLanguage
+	}
+
+	names := make([]string, len(columns))
+	for i, c := range columns {
+		field := stmt.Schema.LookUpField(c)
+		if field == nil || field.DBName == "" {
+			return &ColumnError{c}
+		}
+		names[i] = stmt.Quote(field.DBName)
+	}
+
+	table := stmt.Schema.Table
+	index := stmt.Quote(table + ftsSuffix)
+	list := strings.Join(names, ", ")
+
+	var statements []string
+	switch db.Dialector.Name() {
+	case postgres:
+		Match(columns, "").Language(language).document(stmt)
+		if stmt.Error != nil {
+			return stmt.Error
+		}
+		statements = []string{fmt.Sprintf("CREATE INDEX IF NOT EXISTS %s ON %s USING gin ((%s))", index, stmt.Quote(table), stmt.SQL.String())}
+	case mysql:
+		if db.Migrator().HasIndex(model, table+ftsSuffix) {
+			return nil
+		}
+		statements = []string{fmt.Sprintf("CREATE FULLTEXT INDEX %s ON %s (%s)", index, stmt.Quote(table), list)}
+	default:
+		if stmt.Schema.PrioritizedPrimaryField == nil {
+			return errors.New("match index requires a model with a primary key")
+		}
+		pk := stmt.Quote(stmt.Schema.PrioritizedPrimaryField.DBName)
+
+		values := func(prefix string) string {
+			prefixed := make([]string, len(names))
+			for i, n := range names {
+				prefixed[i] = prefix + n
+			}
+			return strings.Join(prefixed, ", ")
+		}
+
+		insert := fmt.Sprintf("INSERT INTO %s(rowid, %s) VALUES (new.%s, %s);", index, list, pk, values("new."))
+		remove := fmt.Sprintf("INSERT INTO %s(%s, rowid, %s) VALUES ('delete', old.%s, %s);", index, index, list, pk, values("old."))
+
+		statements = []string{
+			fmt.Sprintf("CREATE VIRTUAL TABLE IF NOT EXISTS %s USING fts5(%s, content=%s, content_rowid=%s)",
+				index, list, quoteLiteral(table), quoteLiteral(stmt.Schema.PrioritizedPrimaryField.DBName)),
+			fmt.Sprintf("CREATE TRIGGER IF NOT EXISTS %s AFTER INSERT ON %s BEGIN %s END", stmt.Quote(table+ftsSuffix+"_insert"), stmt.Quote(table), insert),
+			fmt.Sprintf("CREATE TRIGGER IF NOT EXISTS %s AFTER DELETE ON %s BEGIN %s END", stmt.Quote(table+ftsSuffix+"_delete"), stmt.Quote(table), remove),
+			fmt.Sprintf("CREATE TRIGGER IF NOT EXISTS %s AFTER UPDATE ON %s BEGIN %s %s END", stmt.Quote(table+ftsSuffix+"_update"), stmt.Quote(table), remove, insert),
+			fmt.Sprintf("INSERT INTO %s(%s) VALUES ('rebuild')", index, index),
+		}
+	}
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		for _, s := range statements {
+			if err := tx.Exec(s).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// quoteLiteral quotes a value as a SQL string literal.
+func quoteLiteral(value string) string {
+	return "'" + strings.ReplaceAll(value, "'", "''") + "'"
+}
@@ -0,0 +1,131 @@
+package where
+
+import (
+	"testing"
+
+	"github.com/javiorfo/gormen/internal/testutils"
+	"github.com/javiorfo/gormen/pagination/sort"
+)
+
+func TestMatch_Build(t *testing.T) {
+	search := Match([]ColumnName{"username", "password"}, `jo "doe`)
+
+	tests := []struct {
+		name    string
+		dialect string
+		order   bool
+		sql     string
+		vars    []any
+	}{
+		{"SQLite", "sqlite", false,
+			"`users`.`id` in (select rowid from `users_fts` where `users_fts` match ?)", []any{`{username password} : "jo" """doe"`}},
+		{"Postgres", "postgres", false,
+			"to_tsvector('simple', coalesce(`users`.`username`, '') || ' ' || coalesce(`users`.`password`, '')) @@ plainto_tsquery(?, ?)",
+			[]any{"simple", `jo "doe`}},
+		{"MySQL", "mysql", false,
+			"MATCH(`users`.`username`, `users`.`password`) AGAINST (? IN NATURAL LANGUAGE MODE)", []any{`jo "doe`}},
+		{"SQLite relevance", "sqlite", true,
+			"`users`.`id` in (select rowid from `users_fts` where `users_fts` match ?) ORDER BY " +
+				"(select -bm25(`users_fts`) from `users_fts` where `users_fts` match ? and rowid = `users`.`id`) desc",
+			[]any{`{username password} : "jo" """doe"`, `{username password} : "jo" """doe"`}},
+		{"Postgres relevance", "postgres", true,
+			"to_tsvector('simple', coalesce(`users`.`username`, '') || ' ' || coalesce(`users`.`password`, '')) @@ plainto_tsquery(?, ?) ORDER BY " +
+				"ts_rank(to_tsvector('simple', coalesce(`users`.`username`, '') || ' ' || coalesce(`users`.`password`, '')), plainto_tsquery(?, ?)) desc",
+			[]any{"simple", `jo "doe`, "simple", `jo "doe`}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := testutils.SetupDryRunDB(tt.dialect).Where(search)
+			if tt.order {
+				db = db.Order(sort.OrderBy([]sort.Order{search.ByRelevance()}))
+			}
+
+			stmt := db.Find(&[]testutils.UserDB{}).Statement
+			if stmt.Error != nil {
+				t.Fatalf("unexpected error: %v", stmt.Error)
+			}
+
+			expected := "SELECT * FROM `users` WHERE " + tt.sql
+			if sql := stmt.SQL.String(); sql != expected {
+				t.Fatalf("expected %q, got %q", expected, sql)
+			}
+
+			if len(stmt.Vars) != len(tt.vars) {
+				t.Fatalf("expected vars %v, got %v", tt.vars, stmt.Vars)
+			}
+			for i := range tt.vars {
+				if stmt.Vars[i] != tt.vars[i] {
+					t.Errorf("expected var %d to be %v, got %v", i, tt.vars[i], stmt.Vars[i])
+				}
+			}
+		})
+	}
+}
+
+func TestMatch_SQLiteIndex(t *testing.T) {
+	db := testutils.SetupTestDB()
+	conn, _ := db.DB()
+	conn.SetMaxOpenConns(1)
+
+	users := []testutils.UserDB{
+		{Username: "john doe", Password: "x", Person: testutils.PersonDB{Name: "John", Email: "john@mail.com"}},
+		{Username: "jane doe", Password: "john", Person: testutils.PersonDB{Name: "Jane", Email: "jane@mail.com"}},
+	}
+	if err := db.Create(&users[0]).Error; err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := CreateMatchIndex(db, &testutils.UserDB{}, "", "username", "password"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := CreateMatchIndex(db, &testutils.UserDB{}, "", "username", "password"); err != nil {
+		t.Fatalf("expected creating the index again to succeed, got %v", err)
+	}
+
+	if err := db.Create(&users[1]).Error; err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	find := func(query string) []string {
+		search := Match([]ColumnName{"username", "password"}, query)
+
+		var found []testutils.UserDB
+		if err := db.Where(search).Order(sort.OrderBy([]sort.Order{search.ByRelevance()})).Find(&found).Error; err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		names := make([]string, len(found))
+		for i, u := range found {
+			names[i] = u.Username
+		}
+		return names
+	}
+
+	if names := find("doe"); len(names) != 2 {
+		t.Errorf("expected both users, got %v", names)
+	}
+
+	if names := find("john"); len(names) != 2 || names[0] != "john doe" {
+		t.Errorf("expected both users, by relevance, got %v", names)
+	}
+
+	if err := db.Model(&users[0]).Update("username", "johnny").Error; err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := db.Delete(&users[1]).Error; err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if names := find("doe"); len(names) != 0 {
+		t.Errorf("expected index in sync with updates and deletes, got %v", names)
+	}
+
+	if names := find("johnny"); len(names) != 1 {
+		t.Errorf("expected updated user, got %v", names)
+	}
+
+	if err := CreateMatchIndex(db, &testutils.UserDB{}, "", "nickname"); err == nil {
+		t.Error("expected error for unknown column")
+	}
+}
@@ -0,0 +1,97 @@
+package where
+
+import (
+	"strings"
+
+	"gorm.io/gorm/clause"
+)
+
+// escapeChar escapes LIKE wildcards in patterns built from user input.
+// A backslash is avoided because some dialects also treat it as a string literal escape.
+const escapeChar = "!"
+
+// wildcards escapes the LIKE wildcards and the escape character itself.
+var wildcards = strings.NewReplacer(escapeChar, escapeChar+escapeChar, "%", escapeChar+"%", "_", escapeChar+"_")
+
+// pattern represents a SQL LIKE condition, optionally case-insensitive
+// and with its wildcards escaped.
+type pattern struct {
+	name        ColumnName
+	value       string
+	insensitive bool
+	escaped     bool
+}
+
+// Contains constructs a LIKE condition matching values that contain the given text.
+// Wildcards in the text are matched literally.
+func Contains(name ColumnName, value string) pattern {
+	return pattern{name, "%" + wildcards.Replace(value) + "%", false, true}
+}
+
+// StartsWith constructs a LIKE condition matching values that start with the given text.
+// Wildcards in the text are matched literally.
+func StartsWith(name ColumnName, value string) pattern {
+	return pattern{name, wildcards.Replace(value) + "%", false, true}
+}
+
+// EndsWith constructs a LIKE condition matching values that end with the given text.
+// Wildcards in the text are matched literally.
+func EndsWith(name ColumnName, value string) pattern {
+	return pattern{name, "%" + wildcards.Replace(value), false, true}
+}
+
+// ILike constructs a case-insensitive LIKE condition for the given column name and pattern.
+func ILike(name ColumnName, value string) pattern {
+	return pattern{name, value, true, false}
+}
+
+// IContains constructs a case-insensitive version of Contains.
+func IContains(name ColumnName, value string) pattern {
+	p := Contains(name, value)
+	p.insensitive = true
+	return p
+}
+
+// IStartsWith constructs a case-insensitive version of StartsWith.
+func IStartsWith(name ColumnName, value string) pattern {
+	p := StartsWith(name, value)
+	p.insensitive = true
+	return p
+}
+
+// IEndsWith constructs a case-insensitive version of EndsWith.
+func IEndsWith(name ColumnName, value string) pattern {
+	p := EndsWith(name, value)
+	p.insensitive = true
+	return p
+}
+
+// Build writes the SQL LIKE query snippet and its pattern.
+// Case-insensitive patterns use ILIKE on Postgres and compare lowercased values elsewhere.
+// Satisfies Condition interface
+func (p pattern) Build(builder clause.Builder) {
+	format := "%s like ?"
+	if p.insensitive {
+		if dialect(builder) == postgres {
+			format = "%s ilike ?"
+		} else {
+			format = "lower(%s) like lower(?)"
+		}
+	}
+
+	if p.escaped {
+		format += " escape '" + escapeChar + "'"
+	}
+
+	build(builder, p.name, format, p.value)
+}
+
+// Glob constructs a LIKE condition where each * of the pattern matches any sequence of characters,
+// e.g. where.Glob("username", "batch*"). Other characters, LIKE wildcards included, are matched literally.
+func Glob(name ColumnName, value string) pattern {
+	parts := strings.Split(value, "*")
+	for i, part := range parts {
+		parts[i] = wildcards.Replace(part)
+	}
+	return pattern{name, strings.Join(parts, "%"), false, true}
+}
@@ -0,0 +1,41 @@
+package where
+
+import (
+	"testing"
+
+	"github.com/javiorfo/gormen/internal/testutils"
+)
+
+func TestPattern_Build(t *testing.T) {
+	tests := []struct {
+		name      string
+		dialect   string
+		condition Condition
+		sql       string
+		value     string
+	}{
+		{"Contains escapes wildcards", "sqlite", Contains("username", "50%_off!"),
+			"`users`.`username` like ? escape '!'", "%50!%!_off!!%"},
+		{"StartsWith", "sqlite", StartsWith("username", "jd"), "`users`.`username` like ? escape '!'", "jd%"},
+		{"EndsWith", "sqlite", EndsWith("username", "oe"), "`users`.`username` like ? escape '!'", "%oe"},
+		{"Glob", "sqlite", Glob("username", "b_*tch*"), "`users`.`username` like ? escape '!'", "b!_%tch%"},
+		{"ILike on sqlite", "sqlite", ILike("username", "JD%"), "lower(`users`.`username`) like lower(?)", "JD%"},
+		{"ILike on postgres", "postgres", ILike("username", "JD%"), "`users`.`username` ilike ?", "JD%"},
+		{"IContains on postgres", "postgres", IContains("username", "a_b"), "`users`.`username` ilike ? escape '!'", "%a!_b%"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stmt := testutils.SetupDryRunDB(tt.dialect).Where(tt.condition).Find(&[]testutils.UserDB{}).Statement
+
+			expected := "SELECT * FROM `users` WHERE " + tt.sql
+			if sql := stmt.SQL.String(); sql != expected {
+				t.Fatalf("expected %q, got %q", expected, sql)
+			}
+
+			if len(stmt.Vars) != 1 || stmt.Vars[0] != tt.value {
+				t.Errorf("expected value %q, got %v", tt.value, stmt.Vars)
+			}
+		})
+	}
+}
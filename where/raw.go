@@ -0,0 +1,71 @@
+package where
+
+import "gorm.io/gorm/clause"
+
+// NamedArgs maps the named parameters of a raw condition (e.g. @name) to their values.
+type NamedArgs = map[string]any
+
+// raw represents a SQL snippet with named parameters.
+type raw struct {
+	sql  string
+	args NamedArgs
+}
+
+// Raw constructs a condition from a SQL snippet using Gorm named parameters,
+// e.g. where.Raw("coalesce(nickname, username) = @name", where.NamedArgs{"name": "jdoe"}).
+// The snippet is written as is: its identifiers are not checked, so it must never be built from user input.
+func Raw(sql string, args NamedArgs) raw {
+	return raw{sql, args}
+}
+
+// Build writes the SQL snippet inside parentheses, so operators in it keep their precedence
+// when combined with other conditions, binding its named parameters.
+// Satisfies Condition interface
+func (r raw) Build(builder clause.Builder) {
+	builder.WriteByte('(')
+	clause.NamedExpr{SQL: r.sql, Vars: []any{r.args}}.Build(builder)
+	builder.WriteByte(')')
+}
+
+// columnComparison represents a SQL comparison between two columns.
+type columnComparison struct {
+	name   ColumnName
+	other  ColumnName
+	format string
+}
+
+// EqualColumn constructs a condition matching when both columns are equal.
+func EqualColumn(name, other ColumnName) columnComparison {
+	return columnComparison{name, other, "%s = ?"}
+}
+
+// NotEqualColumn constructs a condition matching when both columns are different.
+func NotEqualColumn(name, other ColumnName) columnComparison {
+	return columnComparison{name, other, "%s <> ?"}
+}
+
+// GreaterThanColumn constructs a condition matching when the first column is greater than the other.
+func GreaterThanColumn(name, other ColumnName) columnComparison {
+	return columnComparison{name, other, "%s > ?"}
+}
+
+// GreaterOrEqualColumn constructs a condition matching when the first column is greater than or equal to the other.
+func GreaterOrEqualColumn(name, other ColumnName) columnComparison {
+	return columnComparison{name, other, "%s >= ?"}
+}
+
+// LessThanColumn constructs a condition matching when the first column is less than the other.
+func LessThanColumn(name, other ColumnName) columnComparison {
+	return columnComparison{name, other, "%s < ?"}
+}
+
+// LessOrEqualColumn constructs a condition matching when the first column is less than or equal to the other.
+func LessOrEqualColumn(name, other ColumnName) columnComparison {
+	return columnComparison{name, other, "%s <= ?"}
+}
+
+// Build writes the SQL comparison between both quoted columns.
+// Satisfies Condition interface
+func (c columnComparison) Build(builder clause.Builder) {
+	build(builder, c.name, c.format, column(c.other))
+}
@@ -0,0 +1,45 @@
+package where
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/javiorfo/gormen/internal/testutils"
+)
+
+func TestRaw_Build(t *testing.T) {
+	stmt := testutils.SetupDryRunDB("sqlite").
+		Where(AnyOf(Raw("coalesce(username, password) = @value", NamedArgs{"value": "jdoe"}), Equal("id", 1))).
+		Find(&[]testutils.UserDB{}).Statement
+
+	expected := "SELECT * FROM `users` WHERE ((coalesce(username, password) = ?) OR `users`.`id` = ?)"
+	if sql := stmt.SQL.String(); sql != expected {
+		t.Fatalf("expected %q, got %q", expected, sql)
+	}
+
+	if len(stmt.Vars) != 2 || stmt.Vars[0] != "jdoe" || stmt.Vars[1] != 1 {
+		t.Errorf("unexpected vars %v", stmt.Vars)
+	}
+}
+
+func TestColumnComparison_Build(t *testing.T) {
+	stmt := testutils.SetupDryRunDB("sqlite").
+		Joins("inner join persons p on p.id = users.person_id").
+		Where(GreaterThanColumn("id", "p.id")).
+		Find(&[]testutils.UserDB{}).Statement
+
+	expected := "SELECT `users`.`id`,`users`.`username`,`users`.`password`,`users`.`person_id` FROM `users` " +
+		"inner join persons p on p.id = users.person_id WHERE `users`.`id` > `p`.`id`"
+	if sql := stmt.SQL.String(); sql != expected {
+		t.Fatalf("expected %q, got %q", expected, sql)
+	}
+
+	stmt = testutils.SetupDryRunDB("sqlite").
+		Where(EqualColumn("id", "p.id")).
+		Find(&[]testutils.UserDB{}).Statement
+
+	var columnErr *ColumnError
+	if !errors.As(stmt.Error, &columnErr) || columnErr.Column != "p.id" {
+		t.Errorf("expected column error for p.id, got %v", stmt.Error)
+	}
+}
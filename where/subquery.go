@@ -0,0 +1,113 @@
+package where
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/logger"
+)
+
+// Criteria applies join clauses and conditions to a Gorm query.
+// It is satisfied by gormen.Where.
+type Criteria interface {
+	Apply(*gorm.DB) *gorm.DB
+}
+
+// Correlation links a column of the subquery model to a column of the outer query.
+type Correlation struct {
+	inner ColumnName
+	outer ColumnName
+}
+
+// Correlate constructs a Correlation matching the inner column, of the subquery model,
+// with the outer column, of the query the subquery condition is part of.
+func Correlate(inner, outer ColumnName) Correlation {
+	return Correlation{inner, outer}
+}
+
+// subquery represents a SQL condition over a subquery selecting from another model.
+type subquery struct {
+	name         ColumnName
+	format       string
+	model        any
+	selected     ColumnName
+	criteria     Criteria
+	correlations []Correlation
+}
+
+// Exists constructs an EXISTS condition matching when at least one record of model T
+// satisfies the criteria and the correlations with the outer query.
+func Exists[T any](criteria Criteria, correlations ...Correlation) subquery {
+	return subquery{"", "exists (%s)", new(T), "", criteria, correlations}
+}
+
+// NotExists constructs a NOT EXISTS condition matching when no record of model T
+// satisfies the criteria and the correlations with the outer query.
+func NotExists[T any](criteria Criteria, correlations ...Correlation) subquery {
+	return subquery{"", "not exists (%s)", new(T), "", criteria, correlations}
+}
+
+// InSubquery constructs an IN condition matching the column against the selected column
+// of the records of model T that satisfy the criteria and the correlations with the outer query.
+func InSubquery[T any](name ColumnName, selected ColumnName, criteria Criteria, correlations ...Correlation) subquery {
+	return subquery{name, "in (%s)", new(T), selected, criteria, correlations}
+}
+
+// Build writes the SQL subquery condition and its values.
+// Columns of the criteria and the selected column are checked against model T,
+// while outer columns of the correlations are checked against the outer query.
+// Satisfies Condition interface
+func (s subquery) Build(builder clause.Builder) {
+	stmt, ok := builder.(*gorm.Statement)
+	if !ok {
+		_ = builder.AddError(errors.New("subquery conditions must be built by a Gorm statement"))
+		return
+	}
+
+	db := stmt.DB.Session(&gorm.Session{NewDB: true, DryRun: true, Logger: logger.Discard}).Model(s.model)
+
+	if s.selected != "" {
+		db = db.Select("?", column(s.selected))
+	} else {
+		db = db.Select("1")
+	}
+
+	if s.criteria != nil {
+		db = s.criteria.Apply(db)
+	}
+
+	for _, c := range s.correlations {
+		outer, err := resolveColumn(builder, c.outer)
+		if err != nil {
+			_ = builder.AddError(err)
+			return
+		}
+		if outer.Table == clause.CurrentTable {
+			outer.Table = stmt.Table
+		}
+		db = db.Where(Equal(c.inner, outer))
+	}
+
+	db.Statement.Vars = slices.Clone(stmt.Vars)
+	db = db.Callback().Query().Execute(db)
+	if db.Error != nil {
+		_ = builder.AddError(db.Error)
+		return
+	}
+
+	if s.name != "" {
+		resolved, err := resolveColumn(builder, s.name)
+		if err != nil {
+			_ = builder.AddError(err)
+			return
+		}
+		builder.WriteQuoted(resolved)
+		builder.WriteByte(' ')
+	}
+
+	builder.WriteString(fmt.Sprintf(s.format, db.Statement.SQL.String()))
+	stmt.Vars = db.Statement.Vars
+}
@@ -0,0 +1,63 @@
+package where
+
+import (
+	"testing"
+
+	"github.com/javiorfo/gormen/internal/testutils"
+	"gorm.io/gorm"
+)
+
+// criteria is a minimal Criteria adding conditions joined by AND.
+type criteria []Condition
+
+func (c criteria) Apply(db *gorm.DB) *gorm.DB {
+	for _, cond := range c {
+		db = db.Where(cond)
+	}
+	return db
+}
+
+func TestSubquery_Build(t *testing.T) {
+	tests := []struct {
+		name      string
+		condition Condition
+		sql       string
+		vars      []any
+	}{
+		{"Exists", Exists[testutils.PersonDB](criteria{Equal("name", "jdoe")}, Correlate("id", "person_id")),
+			"exists (SELECT 1 FROM `persons` WHERE `persons`.`name` = ? AND `persons`.`id` = `users`.`person_id`)", []any{"jdoe"}},
+		{"NotExists", NotExists[testutils.PersonDB](nil),
+			"not exists (SELECT 1 FROM `persons`)", nil},
+		{"InSubquery", InSubquery[testutils.PersonDB]("person_id", "id", criteria{Contains("email", "mail")}),
+			"`users`.`person_id` in (SELECT `persons`.`id` FROM `persons` WHERE `persons`.`email` like ? escape '!')", []any{"%mail%"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stmt := testutils.SetupDryRunDB("sqlite").
+				Where(Equal("username", "jdoe")).
+				Where(tt.condition).
+				Where(Equal("password", "1234")).
+				Find(&[]testutils.UserDB{}).Statement
+
+			if stmt.Error != nil {
+				t.Fatalf("unexpected error: %v", stmt.Error)
+			}
+
+			expected := "SELECT * FROM `users` WHERE `users`.`username` = ? AND " + tt.sql + " AND `users`.`password` = ?"
+			if sql := stmt.SQL.String(); sql != expected {
+				t.Fatalf("expected %q, got %q", expected, sql)
+			}
+
+			vars := append(append([]any{"jdoe"}, tt.vars...), "1234")
+			if len(stmt.Vars) != len(vars) {
+				t.Fatalf("expected vars %v, got %v", vars, stmt.Vars)
+			}
+			for i := range vars {
+				if stmt.Vars[i] != vars[i] {
+					t.Errorf("expected var %d to be %v, got %v", i, vars[i], stmt.Vars[i])
+				}
+			}
+		})
+	}
+}
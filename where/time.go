@@ -0,0 +1,80 @@
+package where
+
+import (
+	"time"
+
+	"gorm.io/gorm/clause"
+)
+
+// timeRange represents a half-open time range condition, from inclusive to exclusive.
+// A zero bound leaves that side of the range open.
+type timeRange struct {
+	name ColumnName
+	from time.Time
+	to   time.Time
+}
+
+// OnDate constructs a condition matching times within the calendar day of the date in the location,
+// e.g. where.OnDate("created_at", time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC), madrid)
+// matches from 2026-10-18 00:00 to 2026-10-19 00:00 Madrid time.
+// Only the year, month and day of the date are used. A nil location means UTC.
+func OnDate(name ColumnName, date time.Time, loc *time.Location) timeRange {
+	start := startOfDay(date, loc)
+	return timeRange{name, start, start.AddDate(0, 0, 1)}
+}
+
+// InWeek constructs a condition matching times within the week, Monday to Sunday,
+// that contains the calendar day of the date in the location. A nil location means UTC.
+func InWeek(name ColumnName, date time.Time, loc *time.Location) timeRange {
+	start := startOfDay(date, loc)
+	start = start.AddDate(0, 0, -(int(start.Weekday())+6)%7)
+	return timeRange{name, start, start.AddDate(0, 0, 7)}
+}
+
+// WithinRange constructs a condition matching times from start, inclusive, to end, exclusive.
+// A zero start or end leaves that side of the range open.
+func WithinRange(name ColumnName, start, end time.Time) timeRange {
+	return timeRange{name, start, end}
+}
+
+// Since constructs a condition matching times within the given duration before now,
+// e.g. where.Since("created_at", 7*24*time.Hour) for the last 7 days.
+// Now is taken when the condition is constructed.
+func Since(name ColumnName, duration time.Duration) timeRange {
+	return timeRange{name, time.Now().Add(-duration), time.Time{}}
+}
+
+// Before constructs a condition matching times strictly before the given time.
+func Before(name ColumnName, t time.Time) timeRange {
+	return timeRange{name, time.Time{}, t}
+}
+
+// After constructs a condition matching times strictly after the given time.
+func After(name ColumnName, t time.Time) greaterThan {
+	return GreaterThan(name, t.UTC())
+}
+
+// startOfDay returns midnight of the calendar day of the date in the location.
+func startOfDay(date time.Time, loc *time.Location) time.Time {
+	if loc == nil {
+		loc = time.UTC
+	}
+	year, month, day := date.Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, loc)
+}
+
+// Build writes the SQL range query snippet with its bounds converted to UTC,
+// so it can use an index on the column. A range open on both sides always matches.
+// Satisfies Condition interface
+func (t timeRange) Build(builder clause.Builder) {
+	switch {
+	case !t.from.IsZero() && !t.to.IsZero():
+		build(builder, t.name, "%s >= ? and ? < ?", t.from.UTC(), column(t.name), t.to.UTC())
+	case !t.from.IsZero():
+		build(builder, t.name, "%s >= ?", t.from.UTC())
+	case !t.to.IsZero():
+		build(builder, t.name, "%s < ?", t.to.UTC())
+	default:
+		builder.WriteString("1 = 1")
+	}
+}
@@ -0,0 +1,88 @@
+package where
+
+import (
+	"testing"
+	"time"
+	_ "time/tzdata"
+
+	"github.com/javiorfo/gormen/internal/testutils"
+)
+
+func TestTimeRange_Build(t *testing.T) {
+	madrid, err := time.LoadLocation("Europe/Madrid")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	utc := func(month time.Month, day, hour int) time.Time {
+		return time.Date(2026, month, day, hour, 0, 0, 0, time.UTC)
+	}
+
+	tests := []struct {
+		name      string
+		condition Condition
+		sql       string
+		vars      []any
+	}{
+		{"OnDate", OnDate("created_at", utc(10, 18, 15), madrid),
+			"`products`.`created_at` >= ? and `products`.`created_at` < ?", []any{utc(10, 17, 22), utc(10, 18, 22)}},
+		{"OnDate with daylight saving change", OnDate("created_at", utc(10, 25, 0), madrid),
+			"`products`.`created_at` >= ? and `products`.`created_at` < ?", []any{utc(10, 24, 22), utc(10, 25, 23)}},
+		{"OnDate without location", OnDate("created_at", utc(10, 18, 15), nil),
+			"`products`.`created_at` >= ? and `products`.`created_at` < ?", []any{utc(10, 18, 0), utc(10, 19, 0)}},
+		{"InWeek", InWeek("created_at", utc(10, 18, 0), madrid),
+			"`products`.`created_at` >= ? and `products`.`created_at` < ?", []any{utc(10, 11, 22), utc(10, 18, 22)}},
+		{"WithinRange converts to UTC", WithinRange("created_at", utc(10, 1, 0).In(madrid), utc(11, 1, 0).In(madrid)),
+			"`products`.`created_at` >= ? and `products`.`created_at` < ?", []any{utc(10, 1, 0), utc(11, 1, 0)}},
+		{"WithinRange open end", WithinRange("created_at", utc(10, 1, 0), time.Time{}), "`products`.`created_at` >= ?", []any{utc(10, 1, 0)}},
+		{"WithinRange open", WithinRange("created_at", time.Time{}, time.Time{}), "1 = 1", nil},
+		{"Before", Before("created_at", utc(10, 1, 0)), "`products`.`created_at` < ?", []any{utc(10, 1, 0)}},
+		{"After", After("created_at", utc(10, 1, 0).In(madrid)), "`products`.`created_at` > ?", []any{utc(10, 1, 0)}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stmt := testutils.SetupDryRunDB("sqlite").Where(tt.condition).Find(&[]timedDB{}).Statement
+			if stmt.Error != nil {
+				t.Fatalf("unexpected error: %v", stmt.Error)
+			}
+
+			expected := "SELECT * FROM `products` WHERE " + tt.sql
+			if sql := stmt.SQL.String(); sql != expected {
+				t.Fatalf("expected %q, got %q", expected, sql)
+			}
+
+			if len(stmt.Vars) != len(tt.vars) {
+				t.Fatalf("expected vars %v, got %v", tt.vars, stmt.Vars)
+			}
+			for i := range tt.vars {
+				if v, ok := stmt.Vars[i].(time.Time); !ok || v != tt.vars[i] {
+					t.Errorf("expected var %d to be %v, got %v", i, tt.vars[i], stmt.Vars[i])
+				}
+			}
+		})
+	}
+}
+
+func TestSince_Build(t *testing.T) {
+	before := time.Now().Add(-time.Hour)
+	stmt := testutils.SetupDryRunDB("sqlite").Where(Since("created_at", time.Hour)).Find(&[]timedDB{}).Statement
+
+	if len(stmt.Vars) != 1 {
+		t.Fatalf("expected 1 var, got %v", stmt.Vars)
+	}
+
+	since, ok := stmt.Vars[0].(time.Time)
+	if !ok || since.Location() != time.UTC || since.Before(before) || since.After(time.Now().Add(-time.Hour)) {
+		t.Errorf("expected a UTC time an hour ago, got %v", stmt.Vars[0])
+	}
+}
+
+type timedDB struct {
+	ID        uint
+	CreatedAt time.Time
+}
+
+func (timedDB) TableName() string {
+	return "products"
+}